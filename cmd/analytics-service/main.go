@@ -1,12 +1,14 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"net"
 	"os"
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kiribu/jwt-practice/config"
@@ -15,7 +17,11 @@ import (
 	"github.com/kiribu/jwt-practice/internal/analytics/kafka"
 	"github.com/kiribu/jwt-practice/internal/analytics/service"
 	"github.com/kiribu/jwt-practice/internal/analytics/storage"
+	"github.com/kiribu/jwt-practice/pkg/correlation"
 	"github.com/kiribu/jwt-practice/pkg/logger"
+	"github.com/kiribu/jwt-practice/pkg/metrics"
+	"github.com/kiribu/jwt-practice/pkg/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 )
 
@@ -27,6 +33,19 @@ func main() {
 	env := getEnv("APP_ENV", "local")
 	logger.Setup(env)
 
+	shutdownTracing, err := tracing.Setup(context.Background(), "analytics-service", getEnv("OTEL_TRACES_EXPORTER", "none"))
+	if err != nil {
+		slog.Error("Tracing setup error", "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Error("Failed to flush traces", "error", err)
+		}
+	}()
+
 	dbConfig := config.LoadDatabaseConfig()
 
 	db, err := config.ConnectGormDatabase(dbConfig)
@@ -52,6 +71,9 @@ func main() {
 	go consumer.Start()
 	defer consumer.Close()
 
+	metricsServer := metrics.Serve(":" + getEnv("METRICS_PORT", "9103"))
+	defer metricsServer.Close()
+
 	grpcPort := getEnv("ANALYTICS_GRPC_PORT", "50053")
 	listener, err := net.Listen("tcp", ":"+grpcPort)
 	if err != nil {
@@ -59,7 +81,10 @@ func main() {
 		os.Exit(1)
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(correlation.UnaryServerInterceptor(), metrics.UnaryServerInterceptor()),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+	)
 	pb.RegisterAnalyticsServiceServer(grpcServer, analyticsServer)
 
 	slog.Info("Analytics Service (gRPC) started", "port", grpcPort)
@@ -12,9 +12,14 @@ import (
 	"github.com/kiribu/jwt-practice/internal/gateway/client"
 	"github.com/kiribu/jwt-practice/internal/gateway/handlers"
 	customMiddleware "github.com/kiribu/jwt-practice/internal/gateway/middleware"
+	"github.com/kiribu/jwt-practice/internal/gateway/stream"
 	"github.com/kiribu/jwt-practice/pkg/logger"
+	"github.com/kiribu/jwt-practice/pkg/metrics"
+	"github.com/kiribu/jwt-practice/pkg/redis"
+	"github.com/kiribu/jwt-practice/pkg/tracing"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
 )
 
 func init() {
@@ -25,6 +30,19 @@ func main() {
 	env := getEnv("APP_ENV", "local")
 	logger.Setup(env)
 
+	shutdownTracing, err := tracing.Setup(context.Background(), "api-gateway", getEnv("OTEL_TRACES_EXPORTER", "none"))
+	if err != nil {
+		slog.Error("Tracing setup error", "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Error("Failed to flush traces", "error", err)
+		}
+	}()
+
 	// Connect to Auth Service
 	authServiceAddr := getEnv("AUTH_SERVICE_ADDR", "auth-service:50051")
 	authClient, err := client.NewAuthClient(authServiceAddr)
@@ -55,37 +73,117 @@ func main() {
 	defer analyticsClient.Close()
 	slog.Info("API Gateway: Connected to Analytics Service", "addr", analyticsServiceAddr)
 
+	// Connect to Notification Service
+	notificationServiceAddr := getEnv("NOTIFICATION_SERVICE_ADDR", "notification-service:50054")
+	notificationClient, err := client.NewNotificationClient(notificationServiceAddr)
+	if err != nil {
+		slog.Error("Failed to connect to Notification Service", "error", err)
+		os.Exit(1)
+	}
+	defer notificationClient.Close()
+	slog.Info("API Gateway: Connected to Notification Service", "addr", notificationServiceAddr)
+
+	// Redis delivers reminder events from Notification Service for SSE
+	redisAddr := getEnv("REDIS_ADDR", "localhost:6379")
+	redisPassword := getEnv("REDIS_PASSWORD", "")
+	redisClient, err := redis.NewRedisClient(redisAddr, redisPassword)
+	if err != nil {
+		slog.Error("Redis connection error", "error", err)
+		os.Exit(1)
+	}
+	defer redisClient.Close()
+	slog.Info("API Gateway: Connected to Redis", "addr", redisAddr)
+
+	hubCtx, stopHub := context.WithCancel(context.Background())
+	defer stopHub()
+
+	hub := stream.NewHub(redisClient)
+	go hub.Start(hubCtx)
+
+	heartbeat, err := time.ParseDuration(getEnv("SSE_HEARTBEAT_INTERVAL", "15s"))
+	if err != nil {
+		heartbeat = 15 * time.Second
+	}
+
 	authHandler := handlers.NewAuthHandler(authClient)
 	reminderHandler := handlers.NewReminderHandler(reminderClient)
 	analyticsHandler := handlers.NewAnalyticsHandler(analyticsClient)
+	notificationHandler := handlers.NewNotificationHandler(notificationClient)
+	eventsHandler := handlers.NewEventsHandler(hub, heartbeat)
+	adminHandler := handlers.NewAdminHandler(reminderClient)
 
 	e := echo.New()
 	e.HideBanner = true
 
+	// Session IPs come from c.RealIP(); X-Forwarded-For counts only from these proxies
+	e.IPExtractor, err = customMiddleware.IPExtractor(getEnv("TRUSTED_PROXIES", ""))
+	if err != nil {
+		slog.Error("Invalid TRUSTED_PROXIES", "error", err)
+		os.Exit(1)
+	}
+
+	e.Use(otelecho.Middleware("api-gateway", otelecho.WithSkipper(func(c echo.Context) bool {
+		// SSE streams stay open for hours; health checks are noise
+		return c.Path() == "/events/stream" || c.Path() == "/health"
+	})))
+	e.Use(metrics.EchoMiddleware)
+	e.Use(customMiddleware.CorrelationID)
 	e.Use(customMiddleware.SlogLogger)
 	e.Use(middleware.Recover())
 
 	e.POST("/auth/register", authHandler.Register)
 	e.POST("/auth/login", authHandler.Login)
 	e.POST("/auth/refresh", authHandler.Refresh)
+	e.GET("/.well-known/jwks.json", authHandler.JWKS)
+
+	// EventSource cannot send headers, so the token may also come from the query
+	e.GET("/events/stream", eventsHandler.Stream, customMiddleware.BearerFromQuery, authHandler.AuthMiddleware)
 
 	protected := e.Group("")
 	protected.Use(authHandler.AuthMiddleware)
 	protected.POST("/auth/logout", authHandler.Logout)
 	protected.GET("/auth/profile", authHandler.Profile)
+	protected.GET("/auth/sessions", authHandler.ListSessions)
+	protected.DELETE("/auth/sessions", authHandler.RevokeAllSessions)
+	protected.DELETE("/auth/sessions/:id", authHandler.RevokeSession)
 
 	protected.POST("/reminders", reminderHandler.Create)
 	protected.GET("/reminders", reminderHandler.List)
 	protected.GET("/reminders/:id", reminderHandler.Get)
 	protected.PUT("/reminders/:id", reminderHandler.Update)
 	protected.DELETE("/reminders/:id", reminderHandler.Delete)
+	protected.POST("/reminders/:id/snooze", reminderHandler.Snooze)
+	protected.POST("/reminders/:id/acknowledge", reminderHandler.Acknowledge)
+	protected.GET("/reminders/:id/deliveries", reminderHandler.Deliveries)
 
 	protected.GET("/analytics/me", analyticsHandler.GetStats)
 
+	protected.POST("/webhooks", notificationHandler.CreateWebhook)
+	protected.GET("/webhooks", notificationHandler.ListWebhooks)
+	protected.GET("/webhooks/:id", notificationHandler.GetWebhook)
+	protected.PUT("/webhooks/:id", notificationHandler.UpdateWebhook)
+	protected.DELETE("/webhooks/:id", notificationHandler.DeleteWebhook)
+	protected.POST("/webhooks/:id/rotate-secret", notificationHandler.RotateWebhookSecret)
+
+	protected.GET("/notifications/preferences", notificationHandler.GetPreferences)
+	protected.PUT("/notifications/preferences", notificationHandler.UpdatePreferences)
+
+	admin := protected.Group("/admin")
+	admin.Use(customMiddleware.RequireAdmin(getEnv("ADMIN_USERNAMES", "")))
+	admin.GET("/outbox/failed", adminHandler.ListFailedEvents)
+	admin.GET("/outbox/events/:id", adminHandler.GetEvent)
+	admin.POST("/outbox/requeue", adminHandler.RequeueEvents)
+	admin.POST("/outbox/discard", adminHandler.DiscardEvents)
+	admin.GET("/outbox/audit", adminHandler.AuditLog)
+
 	e.GET("/health", func(c echo.Context) error {
 		return c.String(200, "OK")
 	})
 
+	// Scraped on its own port so that /metrics is not reachable through the public listener
+	metricsServer := metrics.Serve(":" + getEnv("METRICS_PORT", "9100"))
+	defer metricsServer.Close()
+
 	port := getEnv("HTTP_PORT", "8080")
 
 	slog.Info("API Gateway (HTTP) started", "port", port)
@@ -93,14 +191,35 @@ func main() {
 		"POST   /register",
 		"POST   /login",
 		"POST   /refresh",
+		"GET    /.well-known/jwks.json",
 		"POST   /auth/logout",
 		"GET    /profile",
+		"GET    /auth/sessions",
+		"DELETE /auth/sessions",
+		"DELETE /auth/sessions/:id",
 		"POST   /reminders",
 		"GET    /reminders",
 		"GET    /reminders/:id",
 		"PUT    /reminders/:id",
 		"DELETE /reminders/:id",
+		"POST   /reminders/:id/snooze",
+		"POST   /reminders/:id/acknowledge",
+		"GET    /reminders/:id/deliveries",
 		"GET    /analytics/me",
+		"POST   /webhooks",
+		"GET    /webhooks",
+		"GET    /webhooks/:id",
+		"PUT    /webhooks/:id",
+		"DELETE /webhooks/:id",
+		"POST   /webhooks/:id/rotate-secret",
+		"GET    /notifications/preferences",
+		"PUT    /notifications/preferences",
+		"GET    /events/stream",
+		"GET    /admin/outbox/failed",
+		"GET    /admin/outbox/events/:id",
+		"POST   /admin/outbox/requeue",
+		"POST   /admin/outbox/discard",
+		"GET    /admin/outbox/audit",
 		"GET    /health",
 	})
 
@@ -115,6 +234,7 @@ func main() {
 	<-quit
 
 	slog.Info("Shutting down API Gateway...")
+	stopHub()
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	e.Shutdown(ctx)
@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"net"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kiribu/jwt-practice/config"
@@ -13,8 +15,13 @@ import (
 	"github.com/kiribu/jwt-practice/internal/auth/grpc/pb"
 	"github.com/kiribu/jwt-practice/internal/auth/service"
 	"github.com/kiribu/jwt-practice/internal/auth/storage"
+	"github.com/kiribu/jwt-practice/pkg/correlation"
 	"github.com/kiribu/jwt-practice/pkg/logger"
+	"github.com/kiribu/jwt-practice/pkg/metrics"
 	"github.com/kiribu/jwt-practice/pkg/redis"
+	"github.com/kiribu/jwt-practice/pkg/tracing"
+	"github.com/kiribu/jwt-practice/utils"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 )
 
@@ -26,6 +33,19 @@ func main() {
 	env := getEnv("APP_ENV", "local")
 	logger.Setup(env)
 
+	shutdownTracing, err := tracing.Setup(context.Background(), "auth-service", getEnv("OTEL_TRACES_EXPORTER", "none"))
+	if err != nil {
+		slog.Error("Tracing setup error", "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Error("Failed to flush traces", "error", err)
+		}
+	}()
+
 	dbConfig := config.LoadDatabaseConfig()
 
 	db, err := config.ConnectGormDatabase(dbConfig)
@@ -49,12 +69,33 @@ func main() {
 	defer redisClient.Close()
 	slog.Info("Auth Service: Successfully connected to Redis")
 
-	store := storage.NewPostgresStorage(db)
-	authService := service.NewAuthService(store, redisClient)
+	// Refresh tokens are stored as HMAC hashes keyed with this pepper
+	pepper := getEnv("REFRESH_TOKEN_PEPPER", "")
+	if len(pepper) < 32 {
+		slog.Error("REFRESH_TOKEN_PEPPER must be set to at least 32 characters")
+		os.Exit(1)
+	}
+
+	// Access tokens are signed with the active key; the others only verify
+	keys, err := utils.LoadKeyRing(getEnv("JWT_KEYS_DIR", "keys"), getEnv("JWT_ACTIVE_KID", ""), []byte(getEnv("JWT_SECRET", "")))
+	if err != nil {
+		slog.Error("JWT key ring error", "error", err)
+		os.Exit(1)
+	}
+	slog.Info("Auth Service: Loaded JWT signing keys", "active_kid", keys.ActiveKeyID(), "keys", len(keys.JWKS()))
+
+	store := storage.NewPostgresStorage(db, []byte(pepper))
+	authService := service.NewAuthService(store, redisClient, keys)
 	authServer := authgrpc.NewAuthServer(authService)
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(correlation.UnaryServerInterceptor(), metrics.UnaryServerInterceptor()),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+	)
 	pb.RegisterAuthServiceServer(grpcServer, authServer)
 
+	metricsServer := metrics.Serve(":" + getEnv("METRICS_PORT", "9101"))
+	defer metricsServer.Close()
+
 	port := getEnv("GRPC_PORT", "50051")
 	listener, err := net.Listen("tcp", ":"+port)
 	if err != nil {
@@ -3,14 +3,30 @@ package main
 import (
 	"context"
 	"log/slog"
+	"net"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/kiribu/jwt-practice/config"
+	"github.com/kiribu/jwt-practice/internal/notification/channel"
+	notificationgrpc "github.com/kiribu/jwt-practice/internal/notification/grpc"
+	"github.com/kiribu/jwt-practice/internal/notification/grpc/pb"
 	"github.com/kiribu/jwt-practice/internal/notification/kafka"
+	"github.com/kiribu/jwt-practice/internal/notification/service"
+	"github.com/kiribu/jwt-practice/internal/notification/storage"
+	"github.com/kiribu/jwt-practice/internal/notification/worker"
+	"github.com/kiribu/jwt-practice/pkg/correlation"
 	"github.com/kiribu/jwt-practice/pkg/logger"
+	"github.com/kiribu/jwt-practice/pkg/metrics"
+	"github.com/kiribu/jwt-practice/pkg/redis"
+	"github.com/kiribu/jwt-practice/pkg/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
 )
 
 func init() {
@@ -21,8 +37,48 @@ func main() {
 	env := getEnv("APP_ENV", "local")
 	logger.Setup(env)
 
+	shutdownTracing, err := tracing.Setup(context.Background(), "notification-service", getEnv("OTEL_TRACES_EXPORTER", "none"))
+	if err != nil {
+		slog.Error("Tracing setup error", "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Error("Failed to flush traces", "error", err)
+		}
+	}()
+
 	slog.Info("Starting Notification Service...")
 
+	dbConfig := config.LoadDatabaseConfig()
+
+	db, err := config.ConnectGormDatabase(dbConfig)
+	if err != nil {
+		slog.Error("DB connection error", "error", err)
+		os.Exit(1)
+	}
+
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	slog.Info("Notification Service: Successfully connected to PostgreSQL with GORM")
+
+	redisAddr := getEnv("REDIS_ADDR", "localhost:6379")
+	redisPassword := getEnv("REDIS_PASSWORD", "")
+	redisClient, err := redis.NewRedisClient(redisAddr, redisPassword)
+	if err != nil {
+		slog.Error("Redis connection error", "error", err)
+		os.Exit(1)
+	}
+	defer redisClient.Close()
+	slog.Info("Notification Service: Successfully connected to Redis")
+
+	store := storage.NewPostgresStorage(db)
+	notificationService := service.NewNotificationService(store, redisClient)
+	notificationServer := notificationgrpc.NewNotificationServer(notificationService)
+
 	brokersEnv := getEnv("KAFKA_BROKERS", "kafka:9092")
 	brokers := strings.Split(brokersEnv, ",")
 
@@ -30,20 +86,100 @@ func main() {
 	topic := getEnv("KAFKA_TOPIC_NOTIFICATIONS", "notifications")
 	groupID := getEnv("KAFKA_GROUP_ID", "notification-workers")
 
-	consumer := kafka.NewConsumer(brokers, topic, groupID)
+	// Producer for delivery receipts back to Reminder Service
+	resultsTopic := getEnv("KAFKA_TOPIC_NOTIFICATION_RESULTS", "notification_results")
+	resultProducer := kafka.NewResultProducer(brokers, resultsTopic)
+	defer func() {
+		if err := resultProducer.Close(); err != nil {
+			slog.Error("Failed to close result producer", "error", err)
+		}
+	}()
+
+	var webhooks *channel.WebhookChannel
+	channels := channel.NewRegistry()
+	for _, name := range strings.Split(getEnv("NOTIFICATION_CHANNELS", "log"), ",") {
+		switch strings.TrimSpace(name) {
+		case "log":
+			channels.Register(channel.NewLogChannel())
+		case "email":
+			channels.Register(channel.NewEmailChannel(channel.SMTPConfig{
+				Host:             getEnv("SMTP_HOST", "localhost"),
+				Port:             getEnv("SMTP_PORT", "25"),
+				Username:         getEnv("SMTP_USERNAME", ""),
+				Password:         getEnv("SMTP_PASSWORD", ""),
+				From:             getEnv("SMTP_FROM", "reminders@localhost"),
+				DefaultRecipient: getEnv("SMTP_DEFAULT_TO", ""),
+			}))
+		case "webhook":
+			webhooks = channel.NewWebhookChannel(store, store, channel.WebhookConfig{
+				MaxAttempts:    getEnvInt("WEBHOOK_MAX_ATTEMPTS", 5),
+				InitialBackoff: getEnvDuration("WEBHOOK_INITIAL_BACKOFF", time.Second),
+				MaxBackoff:     getEnvDuration("WEBHOOK_MAX_BACKOFF", 30*time.Second),
+				Timeout:        getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+			})
+			channels.Register(webhooks)
+		case "push":
+			channels.Register(channel.NewPushChannel(redisClient, channel.PushConfig{
+				HistorySize: int64(getEnvInt("PUSH_HISTORY_SIZE", 100)),
+				HistoryTTL:  getEnvDuration("PUSH_HISTORY_TTL", 24*time.Hour),
+			}))
+		default:
+			slog.Error("Unknown notification channel", "channel", name)
+			os.Exit(1)
+		}
+	}
+
+	dispatcher := service.NewDispatcher(notificationService, store, channels, resultProducer)
+	consumer := kafka.NewConsumer(brokers, topic, groupID, dispatcher)
+
+	// Releases reminders held back by quiet hours
+	deferredInterval := getEnvDuration("DEFERRED_WORKER_INTERVAL", 30*time.Second)
+	deferredWorker := worker.NewDeferredWorker(store, dispatcher, deferredInterval)
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(correlation.UnaryServerInterceptor(), metrics.UnaryServerInterceptor()),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+	)
+	pb.RegisterNotificationServiceServer(grpcServer, notificationServer)
+
+	metricsServer := metrics.Serve(":" + getEnv("METRICS_PORT", "9104"))
+	defer metricsServer.Close()
+
+	port := getEnv("NOTIFICATION_GRPC_PORT", "50054")
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		slog.Error("Failed to start listener", "error", err)
+		os.Exit(1)
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	go consumer.Start(ctx)
+	go deferredWorker.Start(ctx)
+
+	// Failed webhook deliveries are retried here, off the Kafka consumer path
+	if webhooks != nil {
+		retryWorker := worker.NewWebhookRetryWorker(store, webhooks, resultProducer, getEnvDuration("WEBHOOK_RETRY_INTERVAL", 5*time.Second))
+		go retryWorker.Start(ctx)
+	}
+
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			slog.Error("gRPC server error", "error", err)
+			os.Exit(1)
+		}
+	}()
 
-	slog.Info("Notification Service started", "topic", topic)
+	slog.Info("Notification Service started", "topic", topic, "channels", getEnv("NOTIFICATION_CHANNELS", "log"), "port", port)
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	slog.Info("Shutting down Notification Service...")
 	cancel()
+
+	grpcServer.GracefulStop()
 }
 
 func getEnv(key, defaultValue string) string {
@@ -52,3 +188,19 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(getEnv(key, ""))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(getEnv(key, ""))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
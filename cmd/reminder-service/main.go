@@ -6,6 +6,7 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -18,7 +19,13 @@ import (
 	"github.com/kiribu/jwt-practice/internal/reminder/service"
 	"github.com/kiribu/jwt-practice/internal/reminder/storage"
 	"github.com/kiribu/jwt-practice/internal/reminder/worker"
+	"github.com/kiribu/jwt-practice/pkg/correlation"
+	"github.com/kiribu/jwt-practice/pkg/events"
 	"github.com/kiribu/jwt-practice/pkg/logger"
+	"github.com/kiribu/jwt-practice/pkg/metrics"
+	"github.com/kiribu/jwt-practice/pkg/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 )
 
@@ -30,6 +37,19 @@ func main() {
 	env := getEnv("APP_ENV", "local")
 	logger.Setup(env)
 
+	shutdownTracing, err := tracing.Setup(context.Background(), "reminder-service", getEnv("OTEL_TRACES_EXPORTER", "none"))
+	if err != nil {
+		slog.Error("Tracing setup error", "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Error("Failed to flush traces", "error", err)
+		}
+	}()
+
 	dbConfig := config.LoadDatabaseConfig()
 
 	db, err := config.ConnectGormDatabase(dbConfig)
@@ -50,7 +70,12 @@ func main() {
 
 	// Producer for Notifications (for NotificationWorker)
 	notificationTopic := getEnv("KAFKA_TOPIC_NOTIFICATIONS", "notifications")
-	notificationProducer := kafka.NewProducer(brokers, notificationTopic)
+	notificationCodec, err := events.NewCodec(getEnv("KAFKA_ENCODING_NOTIFICATIONS", "json"))
+	if err != nil {
+		slog.Error("Invalid KAFKA_ENCODING_NOTIFICATIONS", "error", err)
+		os.Exit(1)
+	}
+	notificationProducer := kafka.NewProducer(brokers, notificationTopic, notificationCodec)
 	defer func() {
 		if err := notificationProducer.Close(); err != nil {
 			slog.Error("Failed to close notification producer", "error", err)
@@ -59,18 +84,44 @@ func main() {
 
 	// Producer for Lifecycle Events (for OutboxWorker)
 	lifecycleTopic := getEnv("KAFKA_TOPIC_LIFECYCLE", "reminder_lifecycle")
-	lifecycleProducer := kafka.NewProducer(brokers, lifecycleTopic)
+	lifecycleCodec, err := events.NewCodec(getEnv("KAFKA_ENCODING_LIFECYCLE", "json"))
+	if err != nil {
+		slog.Error("Invalid KAFKA_ENCODING_LIFECYCLE", "error", err)
+		os.Exit(1)
+	}
+	lifecycleProducer := kafka.NewProducer(brokers, lifecycleTopic, lifecycleCodec)
 	defer func() {
 		if err := lifecycleProducer.Close(); err != nil {
 			slog.Error("Failed to close lifecycle producer", "error", err)
 		}
 	}()
 
-	reminderService := service.NewReminderService(store)
+	lookahead, err := time.ParseDuration(getEnv("SCHEDULER_LOOKAHEAD", "1m"))
+	if err != nil {
+		slog.Error("Invalid SCHEDULER_LOOKAHEAD", "error", err)
+		os.Exit(1)
+	}
+
+	batchSize, err := strconv.Atoi(getEnv("SCHEDULER_BATCH_SIZE", "100"))
+	if err != nil || batchSize <= 0 {
+		slog.Error("Invalid SCHEDULER_BATCH_SIZE", "value", getEnv("SCHEDULER_BATCH_SIZE", ""))
+		os.Exit(1)
+	}
+
+	notificationWorker := worker.NewNotificationWorker(store, lookahead, batchSize)
+	reminderService := service.NewReminderService(store, notificationWorker)
 	reminderServer := remindergrpc.NewReminderServer(reminderService)
+	adminServer := remindergrpc.NewAdminServer(service.NewOutboxAdminService(store))
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(correlation.UnaryServerInterceptor(), metrics.UnaryServerInterceptor()),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+	)
 	pb.RegisterReminderServiceServer(grpcServer, reminderServer)
+	pb.RegisterReminderAdminServiceServer(grpcServer, adminServer)
+
+	metricsServer := metrics.Serve(":" + getEnv("METRICS_PORT", "9102"))
+	defer metricsServer.Close()
 
 	port := getEnv("REMINDER_GRPC_PORT", "50052")
 	listener, err := net.Listen("tcp", ":"+port)
@@ -81,21 +132,47 @@ func main() {
 
 	slog.Info("Reminder Service (gRPC) started", "port", port)
 
-	intervalStr := getEnv("WORKER_INTERVAL", "5s")
-	interval, err := time.ParseDuration(intervalStr)
+	// Outbox writes raise NOTIFY; polling only covers missed notifications
+	outboxPollInterval, err := time.ParseDuration(getEnv("OUTBOX_POLL_INTERVAL", "10s"))
 	if err != nil {
-		slog.Error("Invalid WORKER_INTERVAL", "error", err)
+		slog.Error("Invalid OUTBOX_POLL_INTERVAL", "error", err)
 		os.Exit(1)
 	}
 
-	notificationWorker := worker.NewNotificationWorker(store, interval)
-	outboxWorker := worker.NewOutboxWorker(store, lifecycleProducer, notificationProducer, 500*time.Millisecond)
+	outboxBackoff := worker.DefaultBackoffPolicy()
+	outboxBackoff.Base = getEnvDuration("OUTBOX_RETRY_BASE", outboxBackoff.Base)
+	outboxBackoff.Factor = getEnvFloat("OUTBOX_RETRY_FACTOR", outboxBackoff.Factor)
+	outboxBackoff.Max = getEnvDuration("OUTBOX_RETRY_MAX", outboxBackoff.Max)
+	outboxBackoff.Jitter = getEnvFloat("OUTBOX_RETRY_JITTER", outboxBackoff.Jitter)
+	outboxBackoff.MaxAttempts = getEnvInt("OUTBOX_MAX_ATTEMPTS", outboxBackoff.MaxAttempts)
+
+	outboxListener := storage.NewListener(dbConfig.ConnectionString(), storage.OutboxNotifyChannel)
+	outboxWorker := worker.NewOutboxWorker(store, lifecycleProducer, notificationProducer, outboxPollInterval, outboxListener.C(), outboxBackoff)
+
+	retentionWorker := worker.NewRetentionWorker(store, worker.RetentionConfig{
+		Interval:  getEnvDuration("OUTBOX_RETENTION_INTERVAL", time.Hour),
+		MaxAge:    getEnvDuration("OUTBOX_RETENTION_MAX_AGE", 7*24*time.Hour),
+		BatchSize: getEnvInt("OUTBOX_RETENTION_BATCH_SIZE", 1000),
+		DryRun:    getEnvBool("OUTBOX_RETENTION_DRY_RUN", false),
+	})
+
+	outboxCollector := worker.NewOutboxCollector(store, retentionWorker, getEnvDuration("OUTBOX_METRICS_INTERVAL", 30*time.Second))
+	prometheus.MustRegister(outboxCollector)
+
+	// Consumer for delivery receipts from Notification Service
+	resultsTopic := getEnv("KAFKA_TOPIC_NOTIFICATION_RESULTS", "notification_results")
+	resultsGroupID := getEnv("KAFKA_GROUP_NOTIFICATION_RESULTS", "reminder-service-results")
+	resultConsumer := kafka.NewResultConsumer(brokers, resultsTopic, resultsGroupID, reminderService)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	go notificationWorker.Start(ctx)
+	go outboxListener.Start(ctx)
 	go outboxWorker.Start(ctx)
+	go retentionWorker.Start(ctx)
+	go outboxCollector.Start(ctx)
+	go resultConsumer.Start(ctx)
 
 	go func() {
 		if err := grpcServer.Serve(listener); err != nil {
@@ -121,3 +198,35 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(getEnv(key, ""))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(getEnv(key, ""), 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(getEnv(key, ""))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(getEnv(key, ""))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,49 @@
+// schema-check compares the compiled event protos with the committed snapshot
+// and exits non-zero if a change breaks existing Kafka consumers.
+//
+//	go run ./cmd/schema-check          # check
+//	go run ./cmd/schema-check -update  # accept the current schema
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/kiribu/jwt-practice/pkg/events/pb"
+	"github.com/kiribu/jwt-practice/pkg/events/schema"
+)
+
+func main() {
+	lockPath := flag.String("lock", "proto/events.lock.json", "snapshot of the last accepted event schema")
+	update := flag.Bool("update", false, "overwrite the snapshot with the current schema after checking it")
+	flag.Parse()
+
+	current := schema.FromFile(pb.File_proto_events_proto)
+
+	old, err := schema.Load(*lockPath)
+	if os.IsNotExist(err) && *update {
+		old = current
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, "schema-check:", err)
+		os.Exit(1)
+	}
+
+	if problems := schema.Check(old, current); len(problems) > 0 {
+		fmt.Fprintln(os.Stderr, "schema-check: breaking changes to proto/events.proto:")
+		for _, p := range problems {
+			fmt.Fprintln(os.Stderr, "  -", p)
+		}
+		os.Exit(1)
+	}
+
+	if *update {
+		if err := schema.Save(*lockPath, current); err != nil {
+			fmt.Fprintln(os.Stderr, "schema-check:", err)
+			os.Exit(1)
+		}
+		fmt.Println("schema-check: snapshot updated")
+		return
+	}
+	fmt.Println("schema-check: event schema is backward compatible")
+}
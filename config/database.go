@@ -9,6 +9,7 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	gormtracing "gorm.io/plugin/opentelemetry/tracing"
 )
 
 type DatabaseConfig struct {
@@ -47,6 +48,11 @@ func ConnectGormDatabase(config *DatabaseConfig) (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to connect to DB with GORM: %w", err)
 	}
 
+	// One span per query under the caller's span; values stay out of traces
+	if err := db.Use(gormtracing.NewPlugin(gormtracing.WithoutMetrics(), gormtracing.WithoutQueryVariables())); err != nil {
+		return nil, fmt.Errorf("failed to register GORM tracing: %w", err)
+	}
+
 	sqlDB, err := db.DB()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get underlying DB: %w", err)
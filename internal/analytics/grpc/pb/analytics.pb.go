@@ -66,17 +66,19 @@ func (x *GetUserStatsRequest) GetUserId() string {
 }
 
 type UserStatsResponse struct {
-	state                   protoimpl.MessageState `protogen:"open.v1"`
-	UserId                  string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // UUID as string
-	TotalRemindersCreated   int64                  `protobuf:"varint,2,opt,name=total_reminders_created,json=totalRemindersCreated,proto3" json:"total_reminders_created,omitempty"`
-	TotalRemindersCompleted int64                  `protobuf:"varint,3,opt,name=total_reminders_completed,json=totalRemindersCompleted,proto3" json:"total_reminders_completed,omitempty"`
-	TotalRemindersDeleted   int64                  `protobuf:"varint,4,opt,name=total_reminders_deleted,json=totalRemindersDeleted,proto3" json:"total_reminders_deleted,omitempty"`
-	ActiveReminders         int64                  `protobuf:"varint,5,opt,name=active_reminders,json=activeReminders,proto3" json:"active_reminders,omitempty"`
-	CompletionRate          float64                `protobuf:"fixed64,6,opt,name=completion_rate,json=completionRate,proto3" json:"completion_rate,omitempty"`
-	FirstReminderAt         string                 `protobuf:"bytes,7,opt,name=first_reminder_at,json=firstReminderAt,proto3" json:"first_reminder_at,omitempty"`
-	LastActivityAt          string                 `protobuf:"bytes,8,opt,name=last_activity_at,json=lastActivityAt,proto3" json:"last_activity_at,omitempty"`
-	unknownFields           protoimpl.UnknownFields
-	sizeCache               protoimpl.SizeCache
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	UserId                     string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // UUID as string
+	TotalRemindersCreated      int64                  `protobuf:"varint,2,opt,name=total_reminders_created,json=totalRemindersCreated,proto3" json:"total_reminders_created,omitempty"`
+	TotalRemindersCompleted    int64                  `protobuf:"varint,3,opt,name=total_reminders_completed,json=totalRemindersCompleted,proto3" json:"total_reminders_completed,omitempty"`
+	TotalRemindersDeleted      int64                  `protobuf:"varint,4,opt,name=total_reminders_deleted,json=totalRemindersDeleted,proto3" json:"total_reminders_deleted,omitempty"`
+	ActiveReminders            int64                  `protobuf:"varint,5,opt,name=active_reminders,json=activeReminders,proto3" json:"active_reminders,omitempty"`
+	CompletionRate             float64                `protobuf:"fixed64,6,opt,name=completion_rate,json=completionRate,proto3" json:"completion_rate,omitempty"`
+	FirstReminderAt            string                 `protobuf:"bytes,7,opt,name=first_reminder_at,json=firstReminderAt,proto3" json:"first_reminder_at,omitempty"`
+	LastActivityAt             string                 `protobuf:"bytes,8,opt,name=last_activity_at,json=lastActivityAt,proto3" json:"last_activity_at,omitempty"`
+	TotalRemindersSnoozed      int64                  `protobuf:"varint,9,opt,name=total_reminders_snoozed,json=totalRemindersSnoozed,proto3" json:"total_reminders_snoozed,omitempty"`
+	TotalRemindersAcknowledged int64                  `protobuf:"varint,10,opt,name=total_reminders_acknowledged,json=totalRemindersAcknowledged,proto3" json:"total_reminders_acknowledged,omitempty"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
 }
 
 func (x *UserStatsResponse) Reset() {
@@ -165,13 +167,27 @@ func (x *UserStatsResponse) GetLastActivityAt() string {
 	return ""
 }
 
+func (x *UserStatsResponse) GetTotalRemindersSnoozed() int64 {
+	if x != nil {
+		return x.TotalRemindersSnoozed
+	}
+	return 0
+}
+
+func (x *UserStatsResponse) GetTotalRemindersAcknowledged() int64 {
+	if x != nil {
+		return x.TotalRemindersAcknowledged
+	}
+	return 0
+}
+
 var File_proto_analytics_proto protoreflect.FileDescriptor
 
 const file_proto_analytics_proto_rawDesc = "" +
 	"\n" +
 	"\x15proto/analytics.proto\x12\tanalytics\".\n" +
 	"\x13GetUserStatsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x82\x03\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xfc\x03\n" +
 	"\x11UserStatsResponse\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x126\n" +
 	"\x17total_reminders_created\x18\x02 \x01(\x03R\x15totalRemindersCreated\x12:\n" +
@@ -180,7 +196,10 @@ const file_proto_analytics_proto_rawDesc = "" +
 	"\x10active_reminders\x18\x05 \x01(\x03R\x0factiveReminders\x12'\n" +
 	"\x0fcompletion_rate\x18\x06 \x01(\x01R\x0ecompletionRate\x12*\n" +
 	"\x11first_reminder_at\x18\a \x01(\tR\x0ffirstReminderAt\x12(\n" +
-	"\x10last_activity_at\x18\b \x01(\tR\x0elastActivityAt2`\n" +
+	"\x10last_activity_at\x18\b \x01(\tR\x0elastActivityAt\x126\n" +
+	"\x17total_reminders_snoozed\x18\t \x01(\x03R\x15totalRemindersSnoozed\x12@\n" +
+	"\x1ctotal_reminders_acknowledged\x18\n" +
+	" \x01(\x03R\x1atotalRemindersAcknowledged2`\n" +
 	"\x10AnalyticsService\x12L\n" +
 	"\fGetUserStats\x12\x1e.analytics.GetUserStatsRequest\x1a\x1c.analytics.UserStatsResponseB;Z9github.com/kiribu/jwt-practice/internal/analytics/grpc/pbb\x06proto3"
 
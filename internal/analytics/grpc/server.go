@@ -34,12 +34,14 @@ func (s *AnalyticsServer) GetUserStats(ctx context.Context, req *pb.GetUserStats
 
 func convertToProto(s *models.UserStatistics) *pb.UserStatsResponse {
 	resp := &pb.UserStatsResponse{
-		UserId:                  s.UserID.String(),
-		TotalRemindersCreated:   s.TotalRemindersCreated,
-		TotalRemindersCompleted: s.TotalRemindersCompleted,
-		TotalRemindersDeleted:   s.TotalRemindersDeleted,
-		ActiveReminders:         s.ActiveReminders,
-		CompletionRate:          s.CompletionRate,
+		UserId:                     s.UserID.String(),
+		TotalRemindersCreated:      s.TotalRemindersCreated,
+		TotalRemindersCompleted:    s.TotalRemindersCompleted,
+		TotalRemindersDeleted:      s.TotalRemindersDeleted,
+		ActiveReminders:            s.ActiveReminders,
+		CompletionRate:             s.CompletionRate,
+		TotalRemindersSnoozed:      s.TotalRemindersSnoozed,
+		TotalRemindersAcknowledged: s.TotalRemindersAcknowledged,
 	}
 	if s.FirstReminderAt != nil {
 		resp.FirstReminderAt = s.FirstReminderAt.Format(time.RFC3339)
@@ -3,11 +3,16 @@ package kafka
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"time"
 
 	"github.com/kiribu/jwt-practice/internal/analytics/service"
 	"github.com/kiribu/jwt-practice/models"
+	"github.com/kiribu/jwt-practice/pkg/correlation"
+	"github.com/kiribu/jwt-practice/pkg/events"
+	"github.com/kiribu/jwt-practice/pkg/metrics"
+	"github.com/kiribu/jwt-practice/pkg/tracing"
 	"github.com/segmentio/kafka-go"
 )
 
@@ -42,28 +47,76 @@ func (c *Consumer) Start() {
 			continue
 		}
 
-		var event models.LifecycleEvent
-		if err := json.Unmarshal(m.Value, &event); err != nil {
-			slog.Error("Error unmarshalling event", "error", err)
-			continue
-		}
+		c.handleMessage(m)
+	}
+}
 
-		processCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		err = c.service.ProcessEvent(processCtx, event)
-		cancel()
+func (c *Consumer) handleMessage(m kafka.Message) {
+	group := c.reader.Config().GroupID
+	metrics.ObserveFetch(m, group)
 
-		if err != nil {
-			slog.Error("Error processing event", "error", err)
-		} else {
-			commitCtx, commitCancel := context.WithTimeout(context.Background(), 5*time.Second)
-			if err := c.reader.CommitMessages(commitCtx, m); err != nil {
-				slog.Error("Error committing message", "error", err, "partition", m.Partition, "offset", m.Offset)
-			}
-			commitCancel()
-		}
+	ctx := correlation.FromKafka(context.Background(), m.Headers)
+	ctx, span := tracing.StartProcess(ctx, m, group)
+	var err error
+	defer func() { tracing.End(span, err) }()
+
+	event, err := decodeEvent(m)
+	if errors.Is(err, models.ErrUnsupportedEvent) {
+		// Newer producers may emit versions this build does not understand; skip them explicitly
+		slog.WarnContext(ctx, "Rejecting unsupported event", "error", err, "partition", m.Partition, "offset", m.Offset)
+		metrics.ConsumerError(m, group, metrics.ReasonUnsupported)
+		c.commit(m)
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Error unmarshalling event", "error", err)
+		metrics.ConsumerError(m, group, metrics.ReasonDecode)
+		return
+	}
+
+	processCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	err = c.service.ProcessEvent(processCtx, event)
+	cancel()
+
+	if err != nil {
+		slog.ErrorContext(ctx, "Error processing event", "error", err)
+		metrics.ConsumerError(m, group, metrics.ReasonProcess)
+	} else {
+		c.commit(m)
 	}
 }
 
+func (c *Consumer) commit(m kafka.Message) {
+	commitCtx, commitCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer commitCancel()
+
+	if err := c.reader.CommitMessages(commitCtx, m); err != nil {
+		slog.Error("Error committing message", "error", err, "partition", m.Partition, "offset", m.Offset)
+		metrics.ConsumerError(m, c.reader.Config().GroupID, metrics.ReasonCommit)
+	}
+}
+
+// decodeEvent reads a v1 lifecycle envelope in whatever encoding the message
+// header names, or a bare JSON LifecycleEvent written before envelopes were introduced.
+func decodeEvent(m kafka.Message) (models.LifecycleEvent, error) {
+	codec, err := events.CodecFor(m.Headers)
+	if err != nil {
+		return models.LifecycleEvent{}, err
+	}
+
+	envelope, err := codec.Decode(m.Value)
+	if errors.Is(err, models.ErrNoEnvelope) {
+		var event models.LifecycleEvent
+		err = json.Unmarshal(m.Value, &event)
+		return event, err
+	}
+	if err != nil {
+		return models.LifecycleEvent{}, err
+	}
+
+	return models.LifecycleEventFromEnvelope(envelope)
+}
+
 func (c *Consumer) Close() error {
 	return c.reader.Close()
 }
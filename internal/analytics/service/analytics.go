@@ -20,7 +20,7 @@ func NewAnalyticsService(storage storage.AnalyticsStorage) *AnalyticsService {
 }
 
 func (s *AnalyticsService) ProcessEvent(ctx context.Context, event models.LifecycleEvent) error {
-	slog.Info("Processing event", "event_id", event.EventID, "type", event.EventType, "user_id", event.UserID)
+	slog.InfoContext(ctx, "Processing event", "event_id", event.EventID, "type", event.EventType, "user_id", event.UserID)
 
 	tx := s.storage.BeginTx(ctx)
 	defer func() {
@@ -35,7 +35,7 @@ func (s *AnalyticsService) ProcessEvent(ctx context.Context, event models.Lifecy
 		return err
 	}
 	if processed {
-		slog.Info("Event already processed, skipping", "event_id", event.EventID)
+		slog.InfoContext(ctx, "Event already processed, skipping", "event_id", event.EventID)
 		tx.Rollback()
 		return nil
 	}
@@ -45,12 +45,18 @@ func (s *AnalyticsService) ProcessEvent(ctx context.Context, event models.Lifecy
 		err = s.storage.IncrementCreated(ctx, tx, event.UserID, event.Timestamp)
 	case "updated":
 		err = nil // No-op for updated
+	case "occurrence_sent":
+		err = nil // Recurring reminder fired but stays active
 	case "notification_sent":
 		err = s.storage.IncrementCompleted(ctx, tx, event.UserID, event.Timestamp)
 	case "deleted":
 		err = s.storage.IncrementDeleted(ctx, tx, event.UserID, event.Timestamp)
+	case "snoozed":
+		err = s.storage.IncrementSnoozed(ctx, tx, event.UserID, event.Timestamp)
+	case "acknowledged":
+		err = s.storage.IncrementAcknowledged(ctx, tx, event.UserID, event.Timestamp)
 	default:
-		slog.Warn("Unknown event type", "type", event.EventType)
+		slog.WarnContext(ctx, "Unknown event type", "type", event.EventType)
 		err = nil
 	}
 
@@ -18,6 +18,8 @@ type AnalyticsStorage interface {
 	IncrementCreated(ctx context.Context, tx *gorm.DB, userID uuid.UUID, timestamp time.Time) error
 	IncrementCompleted(ctx context.Context, tx *gorm.DB, userID uuid.UUID, timestamp time.Time) error
 	IncrementDeleted(ctx context.Context, tx *gorm.DB, userID uuid.UUID, timestamp time.Time) error
+	IncrementSnoozed(ctx context.Context, tx *gorm.DB, userID uuid.UUID, timestamp time.Time) error
+	IncrementAcknowledged(ctx context.Context, tx *gorm.DB, userID uuid.UUID, timestamp time.Time) error
 }
 
 type PostgresStorage struct {
@@ -100,3 +102,34 @@ func (s *PostgresStorage) IncrementDeleted(ctx context.Context, tx *gorm.DB, use
 	`
 	return tx.Exec(query, userID, timestamp).Error
 }
+
+// IncrementSnoozed moves a completed reminder back to active: it will be
+// counted as completed again when the snoozed notification fires.
+func (s *PostgresStorage) IncrementSnoozed(ctx context.Context, tx *gorm.DB, userID uuid.UUID, timestamp time.Time) error {
+	query := `
+		UPDATE analytics.user_statistics SET
+			total_reminders_snoozed = total_reminders_snoozed + 1,
+			total_reminders_completed = GREATEST(total_reminders_completed - 1, 0),
+			active_reminders = active_reminders + 1,
+			last_activity_at = $2,
+			completion_rate = CASE 
+				WHEN total_reminders_created > 0 
+				THEN ROUND((GREATEST(total_reminders_completed - 1, 0)::DECIMAL / total_reminders_created) * 100, 2)
+				ELSE 0 
+			END,
+			updated_at = NOW()
+		WHERE user_id = $1
+	`
+	return tx.Exec(query, userID, timestamp).Error
+}
+
+func (s *PostgresStorage) IncrementAcknowledged(ctx context.Context, tx *gorm.DB, userID uuid.UUID, timestamp time.Time) error {
+	query := `
+		UPDATE analytics.user_statistics SET
+			total_reminders_acknowledged = total_reminders_acknowledged + 1,
+			last_activity_at = $2,
+			updated_at = NOW()
+		WHERE user_id = $1
+	`
+	return tx.Exec(query, userID, timestamp).Error
+}
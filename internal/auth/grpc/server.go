@@ -2,9 +2,12 @@ package authgrpc
 
 import (
 	"context"
+	"errors"
 
+	"github.com/google/uuid"
 	"github.com/kiribu/jwt-practice/internal/auth/grpc/pb"
 	"github.com/kiribu/jwt-practice/internal/auth/service"
+	"github.com/kiribu/jwt-practice/internal/auth/storage"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -41,7 +44,7 @@ func (s *AuthServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Login
 		return nil, status.Error(codes.InvalidArgument, "username and password are required")
 	}
 
-	tokens, err := s.service.Login(ctx, req.Username, req.Password)
+	tokens, err := s.service.Login(ctx, req.Username, req.Password, req.UserAgent, req.Ip)
 	if err != nil {
 		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
 	}
@@ -58,7 +61,7 @@ func (s *AuthServer) Refresh(ctx context.Context, req *pb.RefreshRequest) (*pb.R
 		return nil, status.Error(codes.InvalidArgument, "refresh_token is required")
 	}
 
-	tokens, err := s.service.Refresh(ctx, req.RefreshToken)
+	tokens, err := s.service.Refresh(ctx, req.RefreshToken, req.Ip)
 	if err != nil {
 		return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
 	}
@@ -78,7 +81,7 @@ func (s *AuthServer) ValidateToken(ctx context.Context, req *pb.ValidateTokenReq
 		}, nil
 	}
 
-	username, userID, err := s.service.ValidateToken(ctx, req.AccessToken)
+	username, userID, sessionID, err := s.service.ValidateToken(ctx, req.AccessToken)
 	if err != nil {
 		return &pb.ValidateTokenResponse{
 			Valid: false,
@@ -87,9 +90,10 @@ func (s *AuthServer) ValidateToken(ctx context.Context, req *pb.ValidateTokenReq
 	}
 
 	return &pb.ValidateTokenResponse{
-		Valid:    true,
-		Username: username,
-		UserId:   userID.String(),
+		Valid:     true,
+		Username:  username,
+		UserId:    userID.String(),
+		SessionId: sessionID,
 	}, nil
 }
 
@@ -121,3 +125,99 @@ func (s *AuthServer) GetProfile(ctx context.Context, req *pb.GetProfileRequest)
 		CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}, nil
 }
+
+func (s *AuthServer) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
+	}
+
+	sessions, err := s.service.ListSessions(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	pbSessions := make([]*pb.Session, len(sessions))
+	for i, session := range sessions {
+		pbSessions[i] = &pb.Session{
+			Id:         session.ID.String(),
+			UserAgent:  session.UserAgent,
+			Ip:         session.IP,
+			CreatedAt:  session.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			LastUsedAt: session.LastUsedAt.Format("2006-01-02T15:04:05Z07:00"),
+			Current:    session.ID.String() == req.CurrentSessionId,
+		}
+	}
+
+	return &pb.ListSessionsResponse{Sessions: pbSessions}, nil
+}
+
+func (s *AuthServer) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
+	}
+	sessionID, err := uuid.Parse(req.SessionId)
+	if err != nil {
+		return &pb.RevokeSessionResponse{
+			Success: false,
+			Message: "invalid session_id: " + err.Error(),
+		}, nil
+	}
+
+	if err := s.service.RevokeSession(ctx, userID, sessionID); err != nil {
+		if errors.Is(err, storage.ErrSessionNotFound) {
+			return &pb.RevokeSessionResponse{
+				Success: false,
+				Message: err.Error(),
+			}, nil
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.RevokeSessionResponse{
+		Success: true,
+		Message: "session revoked",
+	}, nil
+}
+
+func (s *AuthServer) RevokeAllSessions(ctx context.Context, req *pb.RevokeAllSessionsRequest) (*pb.RevokeAllSessionsResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
+	}
+
+	except := uuid.Nil
+	if req.ExceptSessionId != "" {
+		if except, err = uuid.Parse(req.ExceptSessionId); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid except_session_id: %v", err)
+		}
+	}
+
+	revoked, err := s.service.RevokeAllSessions(ctx, userID, except)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.RevokeAllSessionsResponse{Revoked: int32(revoked)}, nil
+}
+
+func (s *AuthServer) GetJWKS(ctx context.Context, req *pb.GetJWKSRequest) (*pb.GetJWKSResponse, error) {
+	keys := s.service.JWKS()
+
+	pbKeys := make([]*pb.JSONWebKey, len(keys))
+	for i, key := range keys {
+		pbKeys[i] = &pb.JSONWebKey{
+			Kty: key.Kty,
+			Kid: key.Kid,
+			Use: key.Use,
+			Alg: key.Alg,
+			Crv: key.Crv,
+			X:   key.X,
+			N:   key.N,
+			E:   key.E,
+		}
+	}
+
+	return &pb.GetJWKSResponse{Keys: pbKeys}, nil
+}
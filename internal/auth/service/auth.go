@@ -6,6 +6,7 @@ import (
 	"errors"
 	"log/slog"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,12 +19,14 @@ import (
 type AuthService struct {
 	store storage.Storage
 	redis *redis.Client
+	keys  *utils.KeyRing
 }
 
-func NewAuthService(store storage.Storage, redisClient *redis.Client) *AuthService {
+func NewAuthService(store storage.Storage, redisClient *redis.Client, keys *utils.KeyRing) *AuthService {
 	return &AuthService{
 		store: store,
 		redis: redisClient,
+		keys:  keys,
 	}
 }
 
@@ -38,6 +41,21 @@ type TokenResponse struct {
 	TokenType    string
 }
 
+var (
+	ErrRefreshTokenRevoked = errors.New("token revoked")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected, please log in again")
+	// ErrRevocationCheckFailed rejects an access token whose revocation
+	// status could not be read from Redis.
+	ErrRevocationCheckFailed = errors.New("could not check whether the token was revoked")
+)
+
+// Device details are cut to the size of their sessions columns rather than
+// failing the login.
+const (
+	maxUserAgentLength = 512
+	maxIPLength        = 45
+)
+
 var (
 	usernameRegex = regexp.MustCompile(`^[a-zA-Z0-9_]{3,255}$`)
 	passwordRegex = regexp.MustCompile(`^[a-zA-Z0-9!#$%*]{8,16}$`)
@@ -65,13 +83,25 @@ func (s *AuthService) Register(ctx context.Context, username, password string) (
 	}, nil
 }
 
-func (s *AuthService) Login(ctx context.Context, username, password string) (*TokenResponse, error) {
+// Login starts a new session for the device identified by userAgent and ip.
+func (s *AuthService) Login(ctx context.Context, username, password, userAgent, ip string) (*TokenResponse, error) {
 	user, err := s.store.ValidatePassword(ctx, username, password)
 	if err != nil {
 		return nil, err
 	}
 
-	accessToken, err := utils.GenerateAccessToken(user.Username, user.ID.String())
+	now := time.Now()
+	session := &models.Session{
+		ID:         uuid.Must(uuid.NewV7()),
+		UserID:     user.ID,
+		UserAgent:  truncate(userAgent, maxUserAgentLength),
+		IP:         truncate(ip, maxIPLength),
+		CreatedAt:  now,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(utils.RefreshTokenDuration),
+	}
+
+	accessToken, err := s.keys.GenerateAccessToken(user.Username, user.ID.String(), session.ID.String())
 	if err != nil {
 		return nil, err
 	}
@@ -81,8 +111,7 @@ func (s *AuthService) Login(ctx context.Context, username, password string) (*To
 		return nil, err
 	}
 
-	expiresAt := time.Now().Add(utils.RefreshTokenDuration)
-	if err := s.store.SaveRefreshToken(ctx, refreshToken, user.ID, expiresAt); err != nil {
+	if err := s.store.CreateSession(ctx, session, refreshToken); err != nil {
 		return nil, err
 	}
 
@@ -93,18 +122,27 @@ func (s *AuthService) Login(ctx context.Context, username, password string) (*To
 	}, nil
 }
 
-func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (*TokenResponse, error) {
-	userID, err := s.store.ValidateRefreshToken(ctx, refreshToken)
+// Refresh rotates a refresh token. The presented token is kept as used; if it
+// is ever presented again, its whole family is revoked (see revokeFamily).
+func (s *AuthService) Refresh(ctx context.Context, refreshToken, ip string) (*TokenResponse, error) {
+	current, err := s.store.ValidateRefreshToken(ctx, refreshToken)
 	if err != nil {
 		return nil, err
 	}
+	if current.RevokedAt != nil {
+		return nil, ErrRefreshTokenRevoked
+	}
+	if current.UsedAt != nil {
+		return nil, s.revokeFamily(ctx, current)
+	}
 
-	user, err := s.store.GetUserByID(ctx, userID)
+	user, err := s.store.GetUserByID(ctx, current.UserID)
 	if err != nil {
 		return nil, err
 	}
 
-	accessToken, err := utils.GenerateAccessToken(user.Username, user.ID.String())
+	// The family ID is the session ID
+	accessToken, err := s.keys.GenerateAccessToken(user.Username, user.ID.String(), current.FamilyID.String())
 	if err != nil {
 		return nil, err
 	}
@@ -114,9 +152,13 @@ func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (*TokenR
 		return nil, err
 	}
 
-	s.store.DeleteRefreshToken(ctx, refreshToken)
 	expiresAt := time.Now().Add(utils.RefreshTokenDuration)
-	if err := s.store.SaveRefreshToken(ctx, newRefreshToken, user.ID, expiresAt); err != nil {
+	err = s.store.RotateRefreshToken(ctx, current, newRefreshToken, expiresAt, truncate(ip, maxIPLength))
+	if errors.Is(err, storage.ErrRefreshTokenUsed) {
+		// Another refresh with the same token got there first
+		return nil, s.revokeFamily(ctx, current)
+	}
+	if err != nil {
 		return nil, err
 	}
 
@@ -127,17 +169,71 @@ func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (*TokenR
 	}, nil
 }
 
-func (s *AuthService) ValidateToken(ctx context.Context, token string) (string, uuid.UUID, error) {
-	// Check Blacklist
+// revokeFamily handles a replayed refresh token. Either the client or whoever
+// copied the token holds its successor and there is no telling which, so the
+// whole family's session is revoked and the user has to log in again.
+func (s *AuthService) revokeFamily(ctx context.Context, token *models.RefreshToken) error {
+	refreshTokenReuse.Inc()
+
+	revoked, err := s.store.RevokeSession(ctx, token.UserID, token.FamilyID)
+	if err == nil {
+		s.markSessionRevoked(ctx, token.FamilyID)
+	} else if !errors.Is(err, storage.ErrSessionNotFound) {
+		slog.ErrorContext(ctx, "Failed to revoke refresh token family", "family_id", token.FamilyID, "error", err)
+	}
+	slog.WarnContext(ctx, "Refresh token reuse detected, family revoked",
+		"user_id", token.UserID, "family_id", token.FamilyID, "token_id", token.ID, "revoked", revoked)
+
+	details, _ := json.Marshal(map[string]interface{}{
+		"family_id":      token.FamilyID,
+		"token_id":       token.ID,
+		"used_at":        token.UsedAt,
+		"revoked_tokens": revoked,
+	})
+	event := &models.SecurityEvent{
+		UserID:  token.UserID,
+		Type:    models.SecurityEventRefreshTokenReuse,
+		Details: details,
+	}
+	if err := s.store.CreateSecurityEvent(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "Failed to record security event", "type", event.Type, "user_id", token.UserID, "error", err)
+	}
+
+	return ErrRefreshTokenReused
+}
+
+// ValidateToken returns the username, user ID and session ID of a valid
+// access token. The session ID is empty for tokens issued before sessions.
+func (s *AuthService) ValidateToken(ctx context.Context, token string) (string, uuid.UUID, string, error) {
+	// Check Blacklist. Without Redis a logged-out token cannot be told
+	// apart, so it is rejected rather than let through.
 	val, err := s.redis.Get(ctx, "blacklist:"+token).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		revocationCheckErrors.WithLabelValues("blacklist").Inc()
+		slog.ErrorContext(ctx, "Failed to check token blacklist", "error", err)
+		return "", uuid.Nil, "", ErrRevocationCheckFailed
+	}
 	if err == nil && val == "revoked" {
 		slog.Warn("Blacklist hit for token", "token", token)
-		return "", uuid.Nil, errors.New("token revoked")
+		return "", uuid.Nil, "", errors.New("token revoked")
 	}
 
-	claims, err := utils.ValidateAccessToken(token)
+	claims, err := s.keys.ValidateAccessToken(token)
 	if err != nil {
-		return "", uuid.Nil, err
+		return "", uuid.Nil, "", err
+	}
+
+	// Check Revoked Session, failing closed like the blacklist
+	if claims.SessionID != "" {
+		n, err := s.redis.Exists(ctx, sessionRevokedKey(claims.SessionID)).Result()
+		if err != nil {
+			revocationCheckErrors.WithLabelValues("session").Inc()
+			slog.ErrorContext(ctx, "Failed to check session revocation", "session_id", claims.SessionID, "error", err)
+			return "", uuid.Nil, "", ErrRevocationCheckFailed
+		}
+		if n > 0 {
+			return "", uuid.Nil, "", errors.New("session revoked")
+		}
 	}
 
 	// Check User Cache
@@ -148,15 +244,17 @@ func (s *AuthService) ValidateToken(ctx context.Context, token string) (string,
 		slog.Debug("Cache hit for user", "username", claims.Username)
 		var user models.User
 		if err := json.Unmarshal([]byte(val), &user); err == nil {
-			return user.Username, user.ID, nil
+			userCacheLookups.WithLabelValues("hit").Inc()
+			return user.Username, user.ID, claims.SessionID, nil
 		}
 	}
 
 	// Cache Miss
 	slog.Debug("Cache miss for user", "username", claims.Username)
+	userCacheLookups.WithLabelValues("miss").Inc()
 	user, err := s.store.GetUserByUsername(ctx, claims.Username)
 	if err != nil {
-		return "", uuid.Nil, err
+		return "", uuid.Nil, "", err
 	}
 
 	// Set Cache
@@ -164,7 +262,7 @@ func (s *AuthService) ValidateToken(ctx context.Context, token string) (string,
 		s.redis.Set(ctx, cacheKey, userJSON, utils.AccessTokenDuration)
 	}
 
-	return claims.Username, user.ID, nil
+	return claims.Username, user.ID, claims.SessionID, nil
 }
 
 func (s *AuthService) GetProfile(ctx context.Context, username string) (*UserResponse, error) {
@@ -176,6 +274,7 @@ func (s *AuthService) GetProfile(ctx context.Context, username string) (*UserRes
 		slog.Debug("Cache hit for user profile", "username", username)
 		var user models.User
 		if err := json.Unmarshal([]byte(val), &user); err == nil {
+			userCacheLookups.WithLabelValues("hit").Inc()
 			return &UserResponse{
 				ID:        user.ID,
 				Username:  user.Username,
@@ -186,6 +285,7 @@ func (s *AuthService) GetProfile(ctx context.Context, username string) (*UserRes
 
 	// Cache Miss
 	slog.Debug("Cache miss for user profile", "username", username)
+	userCacheLookups.WithLabelValues("miss").Inc()
 	user, err := s.store.GetUserByUsername(ctx, username)
 	if err != nil {
 		return nil, err
@@ -203,8 +303,83 @@ func (s *AuthService) GetProfile(ctx context.Context, username string) (*UserRes
 	}, nil
 }
 
+// Logout blacklists the access token and ends the session it was issued for,
+// so the session's refresh token stops working too.
 func (s *AuthService) Logout(ctx context.Context, token string) error {
-	return s.redis.Set(ctx, "blacklist:"+token, "revoked", utils.AccessTokenDuration).Err()
+	if err := s.redis.Set(ctx, "blacklist:"+token, "revoked", utils.AccessTokenDuration).Err(); err != nil {
+		return err
+	}
+
+	claims, err := s.keys.ValidateAccessToken(token)
+	if err != nil || claims.SessionID == "" {
+		return nil
+	}
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil
+	}
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return nil
+	}
+
+	err = s.RevokeSession(ctx, userID, sessionID)
+	if errors.Is(err, storage.ErrSessionNotFound) {
+		return nil
+	}
+	return err
+}
+
+// ListSessions returns the user's active sessions, most recently used first.
+func (s *AuthService) ListSessions(ctx context.Context, userID uuid.UUID) ([]models.Session, error) {
+	return s.store.ListSessions(ctx, userID)
+}
+
+// RevokeSession ends one of the user's sessions: its refresh tokens are
+// revoked and access tokens already issued for it stop validating.
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	if _, err := s.store.RevokeSession(ctx, userID, sessionID); err != nil {
+		return err
+	}
+
+	s.markSessionRevoked(ctx, sessionID)
+	return nil
+}
+
+// RevokeAllSessions ends every session of the user except one (uuid.Nil keeps
+// none) and returns how many were revoked.
+func (s *AuthService) RevokeAllSessions(ctx context.Context, userID, except uuid.UUID) (int, error) {
+	ids, err := s.store.RevokeAllSessions(ctx, userID, except)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, id := range ids {
+		s.markSessionRevoked(ctx, id)
+	}
+	return len(ids), nil
+}
+
+// markSessionRevoked rejects the session's outstanding access tokens. They
+// live at most AccessTokenDuration, so the mark can expire with them. The
+// session ID is also published for the gateway to close the session's open
+// event streams, which were authorized once when they connected.
+func (s *AuthService) markSessionRevoked(ctx context.Context, sessionID uuid.UUID) {
+	if err := s.redis.Set(ctx, sessionRevokedKey(sessionID.String()), "revoked", utils.AccessTokenDuration).Err(); err != nil {
+		slog.ErrorContext(ctx, "Failed to mark session revoked", "session_id", sessionID, "error", err)
+	}
+	if err := s.redis.Publish(ctx, models.SessionRevokedChannel, sessionID.String()).Err(); err != nil {
+		slog.ErrorContext(ctx, "Failed to publish session revocation", "session_id", sessionID, "error", err)
+	}
+}
+
+func sessionRevokedKey(sessionID string) string {
+	return "session_revoked:" + sessionID
+}
+
+// JWKS returns the public keys access tokens can be verified with.
+func (s *AuthService) JWKS() []utils.JSONWebKey {
+	return s.keys.JWKS()
 }
 
 func (s *AuthService) validateCredentials(username, password string) error {
@@ -222,3 +397,16 @@ func (s *AuthService) validateCredentials(username, password string) error {
 
 	return nil
 }
+
+// truncate cuts s to at most n characters, the unit of a VARCHAR(n) column,
+// dropping bytes that are not UTF-8 since Postgres rejects them too.
+func truncate(s string, n int) string {
+	s = strings.ToValidUTF8(s, "")
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/internal/auth/storage"
+	"github.com/kiribu/jwt-practice/internal/testdb"
+	"github.com/kiribu/jwt-practice/models"
+	"github.com/kiribu/jwt-practice/utils"
+	"github.com/redis/go-redis/v9"
+)
+
+// memoryStorage keeps users, sessions and refresh tokens the way
+// PostgresStorage does, without hashing the tokens.
+type memoryStorage struct {
+	storage.Storage
+	users    map[uuid.UUID]*models.User
+	sessions map[uuid.UUID]*models.Session
+	tokens   map[string]*models.RefreshToken
+	events   []models.SecurityEvent
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{
+		users:    make(map[uuid.UUID]*models.User),
+		sessions: make(map[uuid.UUID]*models.Session),
+		tokens:   make(map[string]*models.RefreshToken),
+	}
+}
+
+func (s *memoryStorage) CreateUser(ctx context.Context, username, password string) (*models.User, error) {
+	user := &models.User{ID: uuid.New(), Username: username, PasswordHash: password}
+	s.users[user.ID] = user
+	return user, nil
+}
+
+func (s *memoryStorage) GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	if user, ok := s.users[id]; ok {
+		return user, nil
+	}
+	return nil, errors.New("user not found")
+}
+
+func (s *memoryStorage) ValidatePassword(ctx context.Context, username, password string) (*models.User, error) {
+	for _, user := range s.users {
+		if user.Username == username && user.PasswordHash == password {
+			return user, nil
+		}
+	}
+	return nil, errors.New("invalid password")
+}
+
+func (s *memoryStorage) CreateSession(ctx context.Context, session *models.Session, refreshToken string) error {
+	s.sessions[session.ID] = session
+	s.tokens[refreshToken] = &models.RefreshToken{ID: uuid.New(), UserID: session.UserID, FamilyID: session.ID, ExpiresAt: session.ExpiresAt}
+	return nil
+}
+
+// CreateSession in memoryStorage does not check column sizes; the sessions are
+// listed for the tests to do it.
+func (s *memoryStorage) ListSessions(ctx context.Context, userID uuid.UUID) ([]models.Session, error) {
+	var sessions []models.Session
+	for _, session := range s.sessions {
+		if session.UserID == userID {
+			sessions = append(sessions, *session)
+		}
+	}
+	return sessions, nil
+}
+
+func (s *memoryStorage) ValidateRefreshToken(ctx context.Context, token string) (*models.RefreshToken, error) {
+	rt, ok := s.tokens[token]
+	if !ok {
+		return nil, storage.ErrRefreshTokenNotFound
+	}
+	copied := *rt
+	return &copied, nil
+}
+
+func (s *memoryStorage) RotateRefreshToken(ctx context.Context, parent *models.RefreshToken, token string, expiresAt time.Time, ip string) error {
+	for _, rt := range s.tokens {
+		if rt.ID != parent.ID {
+			continue
+		}
+		if rt.UsedAt != nil || rt.RevokedAt != nil {
+			return storage.ErrRefreshTokenUsed
+		}
+		now := time.Now()
+		rt.UsedAt = &now
+	}
+	s.tokens[token] = &models.RefreshToken{ID: uuid.New(), UserID: parent.UserID, FamilyID: parent.FamilyID, ParentID: &parent.ID, ExpiresAt: expiresAt}
+	if session, ok := s.sessions[parent.FamilyID]; ok && ip != "" {
+		session.IP = ip
+	}
+	return nil
+}
+
+func (s *memoryStorage) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) (int64, error) {
+	session, ok := s.sessions[sessionID]
+	if !ok || session.UserID != userID || session.RevokedAt != nil {
+		return 0, storage.ErrSessionNotFound
+	}
+	now := time.Now()
+	session.RevokedAt = &now
+
+	var revoked int64
+	for _, rt := range s.tokens {
+		if rt.FamilyID == sessionID && rt.RevokedAt == nil {
+			rt.RevokedAt = &now
+			revoked++
+		}
+	}
+	return revoked, nil
+}
+
+func (s *memoryStorage) CreateSecurityEvent(ctx context.Context, event *models.SecurityEvent) error {
+	s.events = append(s.events, *event)
+	return nil
+}
+
+// newTestService signs with a fresh Ed25519 key. Redis is unreachable: refresh
+// tokens live in storage, but access tokens cannot be validated.
+func newTestService(t *testing.T, store storage.Storage) *AuthService {
+	t.Helper()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey: %v", err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "test.pem"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	keys, err := utils.LoadKeyRing(dir, "", nil)
+	if err != nil {
+		t.Fatalf("LoadKeyRing: %v", err)
+	}
+
+	redisClient := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1, DialTimeout: 100 * time.Millisecond})
+	t.Cleanup(func() { redisClient.Close() })
+
+	return NewAuthService(store, redisClient, keys)
+}
+
+// Replaying a rotated refresh token revokes its family: the replay fails, and
+// so does the newer token the legitimate client holds.
+func TestRefreshTokenReuseRevokesFamily(t *testing.T) {
+	t.Run("memory", func(t *testing.T) {
+		store := newMemoryStorage()
+		testRefreshTokenReuse(t, store)
+		if len(store.events) != 1 || store.events[0].Type != models.SecurityEventRefreshTokenReuse {
+			t.Errorf("security events = %+v, want one refresh token reuse", store.events)
+		}
+	})
+	t.Run("postgres", func(t *testing.T) {
+		testRefreshTokenReuse(t, storage.NewPostgresStorage(testdb.Open(t), []byte("pepper")))
+	})
+}
+
+func testRefreshTokenReuse(t *testing.T, store storage.Storage) {
+	s := newTestService(t, store)
+	ctx := context.Background()
+
+	if _, err := s.Register(ctx, "alice", "secret1!x"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	login, err := s.Login(ctx, "alice", "secret1!x", "test", "203.0.113.7")
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+
+	rotated, err := s.Refresh(ctx, login.RefreshToken, "")
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	newer, err := s.Refresh(ctx, rotated.RefreshToken, "")
+	if err != nil {
+		t.Fatalf("second Refresh: %v", err)
+	}
+
+	// Someone replays the first token
+	if _, err := s.Refresh(ctx, login.RefreshToken, ""); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("replayed Refresh = %v, want ErrRefreshTokenReused", err)
+	}
+
+	if _, err := s.Refresh(ctx, newer.RefreshToken, ""); !errors.Is(err, ErrRefreshTokenRevoked) {
+		t.Errorf("Refresh with the newest token after the replay = %v, want ErrRefreshTokenRevoked", err)
+	}
+	if _, err := s.Refresh(ctx, rotated.RefreshToken, ""); !errors.Is(err, ErrRefreshTokenRevoked) {
+		t.Errorf("Refresh with a rotated token after the replay = %v, want ErrRefreshTokenRevoked", err)
+	}
+}
+
+// A client may send any User-Agent; a long one is cut to the sessions column
+// instead of failing the login as invalid credentials.
+func TestLoginWithOversizedUserAgent(t *testing.T) {
+	t.Run("memory", func(t *testing.T) {
+		testOversizedUserAgent(t, newMemoryStorage())
+	})
+	t.Run("postgres", func(t *testing.T) {
+		testOversizedUserAgent(t, storage.NewPostgresStorage(testdb.Open(t), []byte("pepper")))
+	})
+}
+
+func testOversizedUserAgent(t *testing.T, store storage.Storage) {
+	s := newTestService(t, store)
+	ctx := context.Background()
+
+	user, err := s.Register(ctx, "bob", "secret1!x")
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	userAgent := strings.Repeat("Mozilla/5.0 ", 100) + "\xff"
+	login, err := s.Login(ctx, "bob", "secret1!x", userAgent, "2001:db8::1%"+strings.Repeat("eth", 20))
+	if err != nil {
+		t.Fatalf("Login with a %d byte User-Agent: %v", len(userAgent), err)
+	}
+	if _, err := s.Refresh(ctx, login.RefreshToken, strings.Repeat("203.0.113.7,", 10)); err != nil {
+		t.Fatalf("Refresh with an oversized IP: %v", err)
+	}
+
+	sessions, err := store.ListSessions(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("ListSessions: %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("got %d sessions, want 1", len(sessions))
+	}
+	if got := sessions[0].UserAgent; got != userAgent[:maxUserAgentLength] {
+		t.Errorf("stored User-Agent = %q (%d bytes), want the first %d characters", got, len(got), maxUserAgentLength)
+	}
+	if got := utf8.RuneCountInString(sessions[0].IP); got > maxIPLength {
+		t.Errorf("stored IP has %d characters, want at most %d", got, maxIPLength)
+	}
+}
+
+// Without Redis a revoked token looks like any other, so none is accepted.
+func TestValidateTokenFailsClosedWithoutRedis(t *testing.T) {
+	s := newTestService(t, newMemoryStorage())
+	ctx := context.Background()
+
+	if _, err := s.Register(ctx, "alice", "secret1!x"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	login, err := s.Login(ctx, "alice", "secret1!x", "test", "203.0.113.7")
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+
+	if _, _, _, err := s.ValidateToken(ctx, login.AccessToken); !errors.Is(err, ErrRevocationCheckFailed) {
+		t.Fatalf("ValidateToken with Redis down = %v, want ErrRevocationCheckFailed", err)
+	}
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var refreshTokenReuse = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "auth_refresh_token_reuse_total",
+	Help: "Replayed refresh tokens; each one revoked its token family.",
+})
+
+// userCacheLookups counts reads of the user:<username> Redis cache; the hit
+// ratio is hit / (hit + miss).
+var userCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "auth_user_cache_lookups_total",
+	Help: "User cache lookups in Redis, by result (hit or miss).",
+}, []string{"result"})
+
+// revocationCheckErrors counts access tokens rejected because Redis could not
+// be asked whether they were revoked, by check (blacklist or session).
+var revocationCheckErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "auth_revocation_check_errors_total",
+	Help: "Access tokens rejected because their revocation status could not be read from Redis, by check.",
+}, []string{"check"})
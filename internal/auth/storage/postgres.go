@@ -2,6 +2,9 @@ package storage
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"time"
 
@@ -9,6 +12,7 @@ import (
 	"github.com/kiribu/jwt-practice/models"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Storage interface {
@@ -16,17 +20,39 @@ type Storage interface {
 	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error)
 	ValidatePassword(ctx context.Context, username, password string) (*models.User, error)
-	SaveRefreshToken(ctx context.Context, token string, userID uuid.UUID, expiresAt time.Time) error
-	ValidateRefreshToken(ctx context.Context, token string) (uuid.UUID, error)
+	CreateSession(ctx context.Context, session *models.Session, refreshToken string) error
+	ValidateRefreshToken(ctx context.Context, token string) (*models.RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, parent *models.RefreshToken, token string, expiresAt time.Time, ip string) error
 	DeleteRefreshToken(ctx context.Context, token string) error
+	ListSessions(ctx context.Context, userID uuid.UUID) ([]models.Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) (int64, error)
+	RevokeAllSessions(ctx context.Context, userID, except uuid.UUID) ([]uuid.UUID, error)
+	CreateSecurityEvent(ctx context.Context, event *models.SecurityEvent) error
 }
 
+var (
+	ErrRefreshTokenNotFound = errors.New("token not found")
+	ErrRefreshTokenExpired  = errors.New("token expired")
+	// ErrRefreshTokenUsed is returned when a token that was already rotated is rotated again.
+	ErrRefreshTokenUsed = errors.New("refresh token already used")
+	ErrSessionNotFound  = errors.New("session not found")
+)
+
 type PostgresStorage struct {
-	db *gorm.DB
+	db     *gorm.DB
+	pepper []byte // Key for refresh token hashes; changing it invalidates every refresh token
+}
+
+func NewPostgresStorage(db *gorm.DB, pepper []byte) *PostgresStorage {
+	return &PostgresStorage{db: db, pepper: pepper}
 }
 
-func NewPostgresStorage(db *gorm.DB) *PostgresStorage {
-	return &PostgresStorage{db: db}
+// tokenHash is what refresh_tokens stores instead of the token, so a database
+// dump holds no usable credentials.
+func (s *PostgresStorage) tokenHash(token string) string {
+	mac := hmac.New(sha256.New, s.pepper)
+	mac.Write([]byte(token))
+	return hex.EncodeToString(mac.Sum(nil))
 }
 
 func (s *PostgresStorage) CreateUser(ctx context.Context, username, password string) (*models.User, error) {
@@ -80,31 +106,150 @@ func (s *PostgresStorage) ValidatePassword(ctx context.Context, username, passwo
 	return user, nil
 }
 
-func (s *PostgresStorage) SaveRefreshToken(ctx context.Context, token string, userID uuid.UUID, expiresAt time.Time) error {
-	refreshToken := &models.RefreshToken{
-		ID:        uuid.Must(uuid.NewV7()),
-		Token:     token,
-		UserID:    userID,
-		ExpiresAt: expiresAt,
-	}
-	return s.db.WithContext(ctx).Create(refreshToken).Error
+// CreateSession stores a new session together with the first refresh token
+// of its family.
+func (s *PostgresStorage) CreateSession(ctx context.Context, session *models.Session, refreshToken string) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&models.RefreshToken{
+			ID:        uuid.Must(uuid.NewV7()),
+			TokenHash: s.tokenHash(refreshToken),
+			UserID:    session.UserID,
+			FamilyID:  session.ID,
+			ExpiresAt: session.ExpiresAt,
+		}).Error
+	})
 }
 
-func (s *PostgresStorage) ValidateRefreshToken(ctx context.Context, token string) (uuid.UUID, error) {
+// ValidateRefreshToken looks a token up and deletes it if it has expired.
+// Used and revoked tokens are returned as is for the caller to judge.
+func (s *PostgresStorage) ValidateRefreshToken(ctx context.Context, token string) (*models.RefreshToken, error) {
 	var rt models.RefreshToken
-	result := s.db.WithContext(ctx).Where("token = ?", token).First(&rt)
+	result := s.db.WithContext(ctx).Where("token_hash = ?", s.tokenHash(token)).First(&rt)
 	if result.Error != nil {
-		return uuid.Nil, errors.New("token not found")
+		return nil, ErrRefreshTokenNotFound
 	}
 
 	if time.Now().After(rt.ExpiresAt) {
 		s.DeleteRefreshToken(ctx, token)
-		return uuid.Nil, errors.New("token expired")
+		return nil, ErrRefreshTokenExpired
 	}
 
-	return rt.UserID, nil
+	return &rt, nil
+}
+
+// RotateRefreshToken marks parent used, stores token as its successor in the
+// same family and records the use on the session. It returns
+// ErrRefreshTokenUsed if parent was already rotated or revoked, including by a
+// concurrent call.
+func (s *PostgresStorage) RotateRefreshToken(ctx context.Context, parent *models.RefreshToken, token string, expiresAt time.Time, ip string) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", parent.ID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRefreshTokenUsed
+		}
+
+		child := &models.RefreshToken{
+			ID:        uuid.Must(uuid.NewV7()),
+			TokenHash: s.tokenHash(token),
+			UserID:    parent.UserID,
+			FamilyID:  parent.FamilyID,
+			ParentID:  &parent.ID,
+			ExpiresAt: expiresAt,
+		}
+		if err := tx.Create(child).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{"last_used_at": now, "expires_at": expiresAt}
+		if ip != "" {
+			updates["ip"] = ip
+		}
+		return tx.Model(&models.Session{}).Where("id = ?", parent.FamilyID).Updates(updates).Error
+	})
+}
+
+// ListSessions returns the user's sessions that are neither revoked nor
+// expired, most recently used first.
+func (s *PostgresStorage) ListSessions(ctx context.Context, userID uuid.UUID) ([]models.Session, error) {
+	var sessions []models.Session
+	err := s.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// RevokeSession revokes a session of the user and every refresh token of its
+// family, and returns how many tokens were still unrevoked. It returns
+// ErrSessionNotFound if the user has no such session or it is already revoked.
+func (s *PostgresStorage) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) (int64, error) {
+	var revoked int64
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.Session{}).
+			Where("id = ? AND user_id = ? AND revoked_at IS NULL", sessionID, userID).
+			Update("revoked_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrSessionNotFound
+		}
+
+		result = tx.Model(&models.RefreshToken{}).
+			Where("family_id = ? AND revoked_at IS NULL", sessionID).
+			Update("revoked_at", now)
+		revoked = result.RowsAffected
+		return result.Error
+	})
+	return revoked, err
+}
+
+// RevokeAllSessions revokes every active session of the user except one
+// (uuid.Nil keeps none) and returns the IDs of the revoked sessions.
+func (s *PostgresStorage) RevokeAllSessions(ctx context.Context, userID, except uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		var sessions []models.Session
+		result := tx.Model(&sessions).
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+			Where("user_id = ? AND revoked_at IS NULL AND id <> ?", userID, except).
+			Update("revoked_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if len(sessions) == 0 {
+			return nil
+		}
+
+		for _, session := range sessions {
+			ids = append(ids, session.ID)
+		}
+		return tx.Model(&models.RefreshToken{}).
+			Where("family_id IN ? AND revoked_at IS NULL", ids).
+			Update("revoked_at", now).Error
+	})
+	return ids, err
 }
 
 func (s *PostgresStorage) DeleteRefreshToken(ctx context.Context, token string) error {
-	return s.db.WithContext(ctx).Where("token = ?", token).Delete(&models.RefreshToken{}).Error
+	return s.db.WithContext(ctx).Where("token_hash = ?", s.tokenHash(token)).Delete(&models.RefreshToken{}).Error
+}
+
+func (s *PostgresStorage) CreateSecurityEvent(ctx context.Context, event *models.SecurityEvent) error {
+	if event.ID == uuid.Nil {
+		event.ID = uuid.Must(uuid.NewV7())
+	}
+	return s.db.WithContext(ctx).Create(event).Error
 }
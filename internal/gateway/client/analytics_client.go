@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/kiribu/jwt-practice/internal/analytics/grpc/pb"
+	"github.com/kiribu/jwt-practice/pkg/correlation"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
@@ -23,6 +25,8 @@ func NewAnalyticsClient(addr string) (*AnalyticsClient, error) {
 	conn, err := grpc.DialContext(ctx, addr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithBlock(),
+		grpc.WithUnaryInterceptor(correlation.UnaryClientInterceptor()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 	)
 	if err != nil {
 		return nil, err
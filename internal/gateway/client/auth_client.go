@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/kiribu/jwt-practice/internal/auth/grpc/pb"
+	"github.com/kiribu/jwt-practice/pkg/correlation"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
@@ -22,6 +24,8 @@ func NewAuthClient(addr string) (*AuthClient, error) {
 	conn, err := grpc.DialContext(ctx, addr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithBlock(),
+		grpc.WithUnaryInterceptor(correlation.UnaryClientInterceptor()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 	)
 	if err != nil {
 		return nil, err
@@ -43,16 +47,19 @@ func (c *AuthClient) Register(ctx context.Context, username, password string) (*
 	})
 }
 
-func (c *AuthClient) Login(ctx context.Context, username, password string) (*pb.LoginResponse, error) {
+func (c *AuthClient) Login(ctx context.Context, username, password, userAgent, ip string) (*pb.LoginResponse, error) {
 	return c.client.Login(ctx, &pb.LoginRequest{
-		Username: username,
-		Password: password,
+		Username:  username,
+		Password:  password,
+		UserAgent: userAgent,
+		Ip:        ip,
 	})
 }
 
-func (c *AuthClient) Refresh(ctx context.Context, refreshToken string) (*pb.RefreshResponse, error) {
+func (c *AuthClient) Refresh(ctx context.Context, refreshToken, ip string) (*pb.RefreshResponse, error) {
 	return c.client.Refresh(ctx, &pb.RefreshRequest{
 		RefreshToken: refreshToken,
+		Ip:           ip,
 	})
 }
 
@@ -73,3 +80,28 @@ func (c *AuthClient) GetProfile(ctx context.Context, username string) (*pb.UserR
 		Username: username,
 	})
 }
+
+func (c *AuthClient) ListSessions(ctx context.Context, userID, currentSessionID string) (*pb.ListSessionsResponse, error) {
+	return c.client.ListSessions(ctx, &pb.ListSessionsRequest{
+		UserId:           userID,
+		CurrentSessionId: currentSessionID,
+	})
+}
+
+func (c *AuthClient) RevokeSession(ctx context.Context, userID, sessionID string) (*pb.RevokeSessionResponse, error) {
+	return c.client.RevokeSession(ctx, &pb.RevokeSessionRequest{
+		UserId:    userID,
+		SessionId: sessionID,
+	})
+}
+
+func (c *AuthClient) RevokeAllSessions(ctx context.Context, userID, exceptSessionID string) (*pb.RevokeAllSessionsResponse, error) {
+	return c.client.RevokeAllSessions(ctx, &pb.RevokeAllSessionsRequest{
+		UserId:          userID,
+		ExceptSessionId: exceptSessionID,
+	})
+}
+
+func (c *AuthClient) GetJWKS(ctx context.Context) (*pb.GetJWKSResponse, error) {
+	return c.client.GetJWKS(ctx, &pb.GetJWKSRequest{})
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/kiribu/jwt-practice/internal/notification/grpc/pb"
+	"github.com/kiribu/jwt-practice/pkg/correlation"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type NotificationClient struct {
+	conn   *grpc.ClientConn
+	client pb.NotificationServiceClient
+}
+
+func NewNotificationClient(addr string) (*NotificationClient, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	slog.Info("Connecting to Notification Service", "addr", addr)
+	conn, err := grpc.DialContext(ctx, addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+		grpc.WithUnaryInterceptor(correlation.UnaryClientInterceptor()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("Connected to Notification Service", "addr", addr)
+
+	return &NotificationClient{
+		conn:   conn,
+		client: pb.NewNotificationServiceClient(conn),
+	}, nil
+}
+
+func (c *NotificationClient) Close() error {
+	return c.conn.Close()
+}
+
+func (c *NotificationClient) CreateWebhook(ctx context.Context, userID, url string) (*pb.WebhookResponse, error) {
+	return c.client.CreateWebhook(ctx, &pb.CreateWebhookRequest{
+		UserId: userID,
+		Url:    url,
+	})
+}
+
+func (c *NotificationClient) ListWebhooks(ctx context.Context, userID string) (*pb.ListWebhooksResponse, error) {
+	return c.client.ListWebhooks(ctx, &pb.ListWebhooksRequest{
+		UserId: userID,
+	})
+}
+
+func (c *NotificationClient) GetWebhook(ctx context.Context, userID, id string) (*pb.WebhookResponse, error) {
+	return c.client.GetWebhook(ctx, &pb.GetWebhookRequest{
+		UserId: userID,
+		Id:     id,
+	})
+}
+
+func (c *NotificationClient) UpdateWebhook(ctx context.Context, userID, id, url string, enabled bool) (*pb.WebhookResponse, error) {
+	return c.client.UpdateWebhook(ctx, &pb.UpdateWebhookRequest{
+		UserId:  userID,
+		Id:      id,
+		Url:     url,
+		Enabled: enabled,
+	})
+}
+
+func (c *NotificationClient) DeleteWebhook(ctx context.Context, userID, id string) (*pb.DeleteWebhookResponse, error) {
+	return c.client.DeleteWebhook(ctx, &pb.DeleteWebhookRequest{
+		UserId: userID,
+		Id:     id,
+	})
+}
+
+func (c *NotificationClient) RotateWebhookSecret(ctx context.Context, userID, id string) (*pb.WebhookResponse, error) {
+	return c.client.RotateWebhookSecret(ctx, &pb.RotateWebhookSecretRequest{
+		UserId: userID,
+		Id:     id,
+	})
+}
+
+func (c *NotificationClient) GetPreferences(ctx context.Context, userID string) (*pb.PreferencesResponse, error) {
+	return c.client.GetPreferences(ctx, &pb.GetPreferencesRequest{
+		UserId: userID,
+	})
+}
+
+func (c *NotificationClient) UpdatePreferences(ctx context.Context, userID string, channels []*pb.ChannelPreference, quietHoursStart, quietHoursEnd, timezone string) (*pb.PreferencesResponse, error) {
+	return c.client.UpdatePreferences(ctx, &pb.UpdatePreferencesRequest{
+		UserId:          userID,
+		Channels:        channels,
+		QuietHoursStart: quietHoursStart,
+		QuietHoursEnd:   quietHoursEnd,
+		Timezone:        timezone,
+	})
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/kiribu/jwt-practice/internal/reminder/grpc/pb"
+	"github.com/kiribu/jwt-practice/pkg/correlation"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
@@ -13,6 +15,7 @@ import (
 type ReminderClient struct {
 	conn   *grpc.ClientConn
 	client pb.ReminderServiceClient
+	admin  pb.ReminderAdminServiceClient
 }
 
 func NewReminderClient(addr string) (*ReminderClient, error) {
@@ -23,6 +26,8 @@ func NewReminderClient(addr string) (*ReminderClient, error) {
 	conn, err := grpc.DialContext(ctx, addr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithBlock(),
+		grpc.WithUnaryInterceptor(correlation.UnaryClientInterceptor()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 	)
 	if err != nil {
 		return nil, err
@@ -32,6 +37,7 @@ func NewReminderClient(addr string) (*ReminderClient, error) {
 	return &ReminderClient{
 		conn:   conn,
 		client: pb.NewReminderServiceClient(conn),
+		admin:  pb.NewReminderAdminServiceClient(conn),
 	}, nil
 }
 
@@ -39,12 +45,14 @@ func (c *ReminderClient) Close() error {
 	return c.conn.Close()
 }
 
-func (c *ReminderClient) Create(ctx context.Context, userID string, title, description, remindAt string) (*pb.ReminderResponse, error) {
+func (c *ReminderClient) Create(ctx context.Context, userID string, title, description, remindAt, rrule, timezone string) (*pb.ReminderResponse, error) {
 	return c.client.CreateReminder(ctx, &pb.CreateReminderRequest{
 		UserId:      userID,
 		Title:       title,
 		Description: description,
 		RemindAt:    remindAt,
+		Rrule:       rrule,
+		Timezone:    timezone,
 	})
 }
 
@@ -62,13 +70,15 @@ func (c *ReminderClient) GetByID(ctx context.Context, userID, id string) (*pb.Re
 	})
 }
 
-func (c *ReminderClient) Update(ctx context.Context, userID, id string, title, description, remindAt string) (*pb.ReminderResponse, error) {
+func (c *ReminderClient) Update(ctx context.Context, userID, id string, title, description, remindAt, rrule, timezone string) (*pb.ReminderResponse, error) {
 	return c.client.UpdateReminder(ctx, &pb.UpdateReminderRequest{
 		UserId:      userID,
 		Id:          id,
 		Title:       title,
 		Description: description,
 		RemindAt:    remindAt,
+		Rrule:       rrule,
+		Timezone:    timezone,
 	})
 }
 
@@ -78,3 +88,58 @@ func (c *ReminderClient) Delete(ctx context.Context, userID, id string) (*pb.Del
 		Id:     id,
 	})
 }
+
+func (c *ReminderClient) Snooze(ctx context.Context, userID, id, duration, remindAt string) (*pb.ReminderResponse, error) {
+	return c.client.SnoozeReminder(ctx, &pb.SnoozeReminderRequest{
+		UserId:   userID,
+		Id:       id,
+		Duration: duration,
+		RemindAt: remindAt,
+	})
+}
+
+func (c *ReminderClient) Acknowledge(ctx context.Context, userID, id string) (*pb.ReminderResponse, error) {
+	return c.client.AcknowledgeReminder(ctx, &pb.AcknowledgeReminderRequest{
+		UserId: userID,
+		Id:     id,
+	})
+}
+
+func (c *ReminderClient) GetDeliveryHistory(ctx context.Context, userID, id string) (*pb.GetDeliveryHistoryResponse, error) {
+	return c.client.GetDeliveryHistory(ctx, &pb.GetDeliveryHistoryRequest{
+		UserId: userID,
+		Id:     id,
+	})
+}
+
+func (c *ReminderClient) ListFailedEvents(ctx context.Context, limit, offset int32) (*pb.ListFailedEventsResponse, error) {
+	return c.admin.ListFailedEvents(ctx, &pb.ListFailedEventsRequest{
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+func (c *ReminderClient) GetOutboxEvent(ctx context.Context, id string) (*pb.OutboxEventResponse, error) {
+	return c.admin.GetEvent(ctx, &pb.GetEventRequest{Id: id})
+}
+
+func (c *ReminderClient) RequeueEvents(ctx context.Context, ids []string, actor string) (*pb.ResolveEventsResponse, error) {
+	return c.admin.RequeueEvents(ctx, &pb.ResolveEventsRequest{
+		Ids:   ids,
+		Actor: actor,
+	})
+}
+
+func (c *ReminderClient) DiscardEvents(ctx context.Context, ids []string, actor string) (*pb.ResolveEventsResponse, error) {
+	return c.admin.DiscardEvents(ctx, &pb.ResolveEventsRequest{
+		Ids:   ids,
+		Actor: actor,
+	})
+}
+
+func (c *ReminderClient) ListOutboxAuditLog(ctx context.Context, limit, offset int32) (*pb.ListAuditLogResponse, error) {
+	return c.admin.ListAuditLog(ctx, &pb.ListAuditLogRequest{
+		Limit:  limit,
+		Offset: offset,
+	})
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kiribu/jwt-practice/internal/gateway/client"
+	"github.com/labstack/echo/v4"
+)
+
+type AdminHandler struct {
+	reminderClient *client.ReminderClient
+}
+
+func NewAdminHandler(reminderClient *client.ReminderClient) *AdminHandler {
+	return &AdminHandler{
+		reminderClient: reminderClient,
+	}
+}
+
+type ResolveEventsRequest struct {
+	IDs []string `json:"ids"`
+}
+
+func (h *AdminHandler) ListFailedEvents(c echo.Context) error {
+	limit, offset := pagination(c)
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.reminderClient.ListFailedEvents(ctx, limit, offset)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp.Events)
+}
+
+func (h *AdminHandler) GetEvent(c echo.Context) error {
+	id := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.reminderClient.GetOutboxEvent(ctx, id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *AdminHandler) RequeueEvents(c echo.Context) error {
+	actor := c.Get("username").(string)
+	var req ResolveEventsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request format"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.reminderClient.RequeueEvents(ctx, req.IDs, actor)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string][]string{"requeued": resp.Ids})
+}
+
+func (h *AdminHandler) DiscardEvents(c echo.Context) error {
+	actor := c.Get("username").(string)
+	var req ResolveEventsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request format"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.reminderClient.DiscardEvents(ctx, req.IDs, actor)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string][]string{"discarded": resp.Ids})
+}
+
+func (h *AdminHandler) AuditLog(c echo.Context) error {
+	limit, offset := pagination(c)
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.reminderClient.ListOutboxAuditLog(ctx, limit, offset)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp.Entries)
+}
+
+// pagination reads ?limit= and ?offset=; invalid values fall back to the service defaults.
+func pagination(c echo.Context) (int32, int32) {
+	limit, _ := strconv.ParseInt(c.QueryParam("limit"), 10, 32)
+	offset, _ := strconv.ParseInt(c.QueryParam("offset"), 10, 32)
+	return int32(limit), int32(offset)
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/kiribu/jwt-practice/internal/gateway/client"
 	"github.com/labstack/echo/v4"
 )
@@ -57,7 +58,7 @@ func (h *AuthHandler) Login(c echo.Context) error {
 	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
 	defer cancel()
 
-	resp, err := h.authClient.Login(ctx, creds.Username, creds.Password)
+	resp, err := h.authClient.Login(ctx, creds.Username, creds.Password, c.Request().UserAgent(), c.RealIP())
 	if err != nil {
 		return c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "Invalid credentials"})
 	}
@@ -74,7 +75,7 @@ func (h *AuthHandler) Refresh(c echo.Context) error {
 	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
 	defer cancel()
 
-	resp, err := h.authClient.Refresh(ctx, req.RefreshToken)
+	resp, err := h.authClient.Refresh(ctx, req.RefreshToken, c.RealIP())
 	if err != nil {
 		return c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "Invalid refresh token"})
 	}
@@ -118,6 +119,80 @@ func (h *AuthHandler) Logout(c echo.Context) error {
 	return c.JSON(http.StatusOK, map[string]string{"message": "Successfully logged out"})
 }
 
+func (h *AuthHandler) ListSessions(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+	sessionID := c.Get("session_id").(string)
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.authClient.ListSessions(ctx, userID, sessionID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch sessions"})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *AuthHandler) RevokeSession(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+	id := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.authClient.RevokeSession(ctx, userID, id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	}
+
+	if !resp.Success {
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: resp.Message})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": resp.Message})
+}
+
+// RevokeAllSessions ends all of the user's sessions, or all but the calling
+// one with ?except_current=true.
+func (h *AuthHandler) RevokeAllSessions(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+
+	var except string
+	if c.QueryParam("except_current") == "true" {
+		except = c.Get("session_id").(string)
+		if except == "" {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Current token is not bound to a session, log in again"})
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.authClient.RevokeAllSessions(ctx, userID, except)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]int32{"revoked": resp.Revoked})
+}
+
+// JWKS serves the public keys access tokens are signed with, so other
+// services can verify tokens without calling the auth service.
+func (h *AuthHandler) JWKS(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.authClient.GetJWKS(ctx)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch keys"})
+	}
+
+	// Short enough that a key added before a rotation is picked up in time
+	c.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return c.JSON(http.StatusOK, resp)
+}
+
 // AuthMiddleware validates the JWT token
 func (h *AuthHandler) AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
@@ -139,9 +214,23 @@ func (h *AuthHandler) AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 			return c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "Invalid token"})
 		}
 
-		// Add username and user_id to context
+		// Add username, user_id and session_id to context
 		c.Set("username", resp.Username)
 		c.Set("user_id", resp.UserId)
+		c.Set("session_id", resp.SessionId) // Empty for tokens issued before sessions
+		if expiresAt, ok := tokenExpiry(parts[1]); ok {
+			c.Set("token_expires_at", expiresAt) // Lets long-lived streams end with the token
+		}
 		return next(c)
 	}
 }
+
+// tokenExpiry reads the exp claim of a token the auth service has already
+// verified, so the signature is not checked again here.
+func tokenExpiry(token string) (time.Time, bool) {
+	var claims jwt.RegisteredClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err != nil || claims.ExpiresAt == nil {
+		return time.Time{}, false
+	}
+	return claims.ExpiresAt.Time, true
+}
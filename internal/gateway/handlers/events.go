@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/internal/gateway/stream"
+	"github.com/kiribu/jwt-practice/models"
+	"github.com/labstack/echo/v4"
+)
+
+type EventsHandler struct {
+	hub       *stream.Hub
+	heartbeat time.Duration
+}
+
+func NewEventsHandler(hub *stream.Hub, heartbeat time.Duration) *EventsHandler {
+	return &EventsHandler{
+		hub:       hub,
+		heartbeat: heartbeat,
+	}
+}
+
+// Stream pushes the user's delivered reminders as Server-Sent Events.
+// Clients resume after a reconnect by sending the Last-Event-ID header
+// (or the last_event_id query parameter on the first connect).
+// The stream ends when the access token it was opened with expires or its
+// session is revoked; the client reconnects with a fresh token.
+func (h *EventsHandler) Stream(c echo.Context) error {
+	userID, err := uuid.Parse(c.Get("user_id").(string))
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "Invalid user"})
+	}
+
+	lastEventID := c.Request().Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.QueryParam("last_event_id")
+	}
+	if lastEventID != "" && !stream.ValidEventID(lastEventID) {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid Last-Event-ID"})
+	}
+
+	// Subscribe before replaying so nothing published in between is lost;
+	// duplicates are filtered by event ID below.
+	sessionID, _ := c.Get("session_id").(string)
+	sub := h.hub.Subscribe(userID, sessionID)
+	defer h.hub.Unsubscribe(sub)
+
+	ctx := c.Request().Context()
+
+	var backlog []models.PushEvent
+	if lastEventID != "" {
+		backlog, err = h.hub.Replay(ctx, userID, lastEventID)
+		if err != nil {
+			slog.Error("Failed to replay events", "user_id", userID, "error", err)
+			return c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to load missed events"})
+		}
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(res, "retry: %d\n\n", 3000)
+	res.Flush()
+
+	for _, event := range backlog {
+		if err := writeEvent(res, event); err != nil {
+			return nil
+		}
+		lastEventID = event.ID
+	}
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	var expired <-chan time.Time
+	if expiresAt, ok := c.Get("token_expires_at").(time.Time); ok {
+		timer := time.NewTimer(time.Until(expiresAt))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-sub.Done():
+			return nil
+		case <-expired:
+			return nil
+		case <-ticker.C:
+			if _, err := fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		case event := <-sub.Events():
+			if lastEventID != "" && !stream.After(event.ID, lastEventID) {
+				continue
+			}
+			if err := writeEvent(res, event); err != nil {
+				return nil
+			}
+			lastEventID = event.ID
+		}
+	}
+}
+
+func writeEvent(res *echo.Response, event models.PushEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(res, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+		return err
+	}
+	res.Flush()
+	return nil
+}
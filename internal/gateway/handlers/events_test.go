@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/internal/gateway/stream"
+	"github.com/labstack/echo/v4"
+)
+
+func TestStreamEndsWhenTokenExpires(t *testing.T) {
+	h := NewEventsHandler(stream.NewHub(nil), time.Hour)
+
+	req := httptest.NewRequest(http.MethodGet, "/events/stream", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.Set("user_id", uuid.NewString())
+	c.Set("session_id", uuid.NewString())
+	c.Set("token_expires_at", time.Now().Add(50*time.Millisecond))
+
+	done := make(chan error, 1)
+	go func() { done <- h.Stream(c) }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Stream: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream stayed open after the token expired")
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200", rec.Code)
+	}
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/kiribu/jwt-practice/internal/gateway/client"
+	"github.com/kiribu/jwt-practice/internal/notification/grpc/pb"
+	"github.com/labstack/echo/v4"
+)
+
+type NotificationHandler struct {
+	notificationClient *client.NotificationClient
+}
+
+func NewNotificationHandler(notificationClient *client.NotificationClient) *NotificationHandler {
+	return &NotificationHandler{
+		notificationClient: notificationClient,
+	}
+}
+
+type CreateWebhookRequest struct {
+	URL string `json:"url"`
+}
+
+type UpdateWebhookRequest struct {
+	URL     string `json:"url"`
+	Enabled bool   `json:"enabled"`
+}
+
+type ChannelPreference struct {
+	Channel string `json:"channel"`
+	Address string `json:"address"`
+}
+
+type UpdatePreferencesRequest struct {
+	Channels        []ChannelPreference `json:"channels"`
+	QuietHoursStart string              `json:"quiet_hours_start"`
+	QuietHoursEnd   string              `json:"quiet_hours_end"`
+	Timezone        string              `json:"timezone"`
+}
+
+func (h *NotificationHandler) CreateWebhook(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+	var req CreateWebhookRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request format"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.notificationClient.CreateWebhook(ctx, userID, req.URL)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, resp)
+}
+
+func (h *NotificationHandler) ListWebhooks(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.notificationClient.ListWebhooks(ctx, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp.Webhooks)
+}
+
+func (h *NotificationHandler) GetWebhook(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+	id := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.notificationClient.GetWebhook(ctx, userID, id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "Webhook not found"})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *NotificationHandler) UpdateWebhook(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+	id := c.Param("id")
+
+	var req UpdateWebhookRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request format"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.notificationClient.UpdateWebhook(ctx, userID, id, req.URL, req.Enabled)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *NotificationHandler) DeleteWebhook(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+	id := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.notificationClient.DeleteWebhook(ctx, userID, id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	}
+
+	if !resp.Success {
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: resp.Message})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": resp.Message})
+}
+
+func (h *NotificationHandler) RotateWebhookSecret(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+	id := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.notificationClient.RotateWebhookSecret(ctx, userID, id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "Webhook not found"})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *NotificationHandler) GetPreferences(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.notificationClient.GetPreferences(ctx, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *NotificationHandler) UpdatePreferences(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+
+	var req UpdatePreferencesRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request format"})
+	}
+
+	channels := make([]*pb.ChannelPreference, 0, len(req.Channels))
+	for _, ch := range req.Channels {
+		channels = append(channels, &pb.ChannelPreference{
+			Channel: ch.Channel,
+			Address: ch.Address,
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.notificationClient.UpdatePreferences(ctx, userID, channels, req.QuietHoursStart, req.QuietHoursEnd, req.Timezone)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/kiribu/jwt-practice/internal/gateway/client"
 	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type ReminderHandler struct {
@@ -23,12 +25,21 @@ type CreateReminderRequest struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	RemindAt    string `json:"remind_at"`
+	RRule       string `json:"rrule"`
+	Timezone    string `json:"timezone"`
 }
 
 type UpdateReminderRequest struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	RemindAt    string `json:"remind_at"`
+	RRule       string `json:"rrule"`
+	Timezone    string `json:"timezone"`
+}
+
+type SnoozeReminderRequest struct {
+	Duration string `json:"duration"`
+	RemindAt string `json:"remind_at"`
 }
 
 func (h *ReminderHandler) Create(c echo.Context) error {
@@ -41,7 +52,7 @@ func (h *ReminderHandler) Create(c echo.Context) error {
 	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
 	defer cancel()
 
-	resp, err := h.reminderClient.Create(ctx, userID, req.Title, req.Description, req.RemindAt)
+	resp, err := h.reminderClient.Create(ctx, userID, req.Title, req.Description, req.RemindAt, req.RRule, req.Timezone)
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
 	}
@@ -93,7 +104,7 @@ func (h *ReminderHandler) Update(c echo.Context) error {
 	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
 	defer cancel()
 
-	resp, err := h.reminderClient.Update(ctx, userID, id, req.Title, req.Description, req.RemindAt)
+	resp, err := h.reminderClient.Update(ctx, userID, id, req.Title, req.Description, req.RemindAt, req.RRule, req.Timezone)
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
 	}
@@ -120,3 +131,69 @@ func (h *ReminderHandler) Delete(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, map[string]string{"message": resp.Message})
 }
+
+func (h *ReminderHandler) Snooze(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+	id := c.Param("id")
+
+	var req SnoozeReminderRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request format"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.reminderClient.Snooze(ctx, userID, id, req.Duration, req.RemindAt)
+	if err != nil {
+		st := status.Convert(err)
+		return c.JSON(actionHTTPStatus(st.Code()), ErrorResponse{Error: st.Message()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *ReminderHandler) Acknowledge(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+	id := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.reminderClient.Acknowledge(ctx, userID, id)
+	if err != nil {
+		st := status.Convert(err)
+		return c.JSON(actionHTTPStatus(st.Code()), ErrorResponse{Error: st.Message()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *ReminderHandler) Deliveries(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+	id := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.reminderClient.GetDeliveryHistory(ctx, userID, id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "Reminder not found"})
+	}
+
+	return c.JSON(http.StatusOK, resp.Deliveries)
+}
+
+// actionHTTPStatus maps the gRPC code of a snooze or acknowledge to HTTP.
+func actionHTTPStatus(code codes.Code) int {
+	switch code {
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.FailedPrecondition:
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
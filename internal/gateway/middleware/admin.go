@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequireAdmin allows only the listed usernames through. It must run after
+// AuthMiddleware, which puts the username into the context. An empty list
+// denies everyone.
+func RequireAdmin(usernames string) echo.MiddlewareFunc {
+	admins := make(map[string]bool)
+	for _, name := range strings.Split(usernames, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			admins[name] = true
+		}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			username, _ := c.Get("username").(string)
+			if !admins[username] {
+				slog.Warn("Admin access denied", "username", username, "path", c.Path())
+				return c.JSON(http.StatusForbidden, map[string]string{"error": "admin access required"})
+			}
+			return next(c)
+		}
+	}
+}
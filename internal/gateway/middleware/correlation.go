@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"github.com/kiribu/jwt-practice/pkg/correlation"
+	"github.com/labstack/echo/v4"
+)
+
+// CorrelationID takes the client's X-Correlation-ID or generates one, echoes
+// it in the response and puts it into the request context, from where gRPC
+// clients forward it to the services.
+func CorrelationID(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+
+		id := req.Header.Get(correlation.HTTPHeader)
+		if !correlation.Valid(id) {
+			id = correlation.New()
+		}
+
+		c.Response().Header().Set(correlation.HTTPHeader, id)
+		c.SetRequest(req.WithContext(correlation.WithID(req.Context(), id)))
+		return next(c)
+	}
+}
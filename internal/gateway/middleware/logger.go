@@ -12,6 +12,7 @@ func SlogLogger(next echo.HandlerFunc) echo.HandlerFunc {
 		start := time.Now()
 
 		err := next(c)
+		ctx := c.Request().Context()
 
 		duration := time.Since(start)
 		status := c.Response().Status
@@ -28,13 +29,13 @@ func SlogLogger(next echo.HandlerFunc) echo.HandlerFunc {
 			attrs = append(attrs, "error", err.Error())
 			// Log as Error if status is 5xx or there is an error
 			if status >= 500 {
-				slog.Error("HTTP Request Failed", attrs...)
+				slog.ErrorContext(ctx, "HTTP Request Failed", attrs...)
 			} else {
-				slog.Warn("HTTP Request Error", attrs...)
+				slog.WarnContext(ctx, "HTTP Request Error", attrs...)
 			}
 		} else {
 			// Log as Info for successful requests
-			slog.Info("HTTP Request", attrs...)
+			slog.InfoContext(ctx, "HTTP Request", attrs...)
 		}
 
 		return err
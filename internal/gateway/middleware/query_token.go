@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// BearerFromQuery lets clients that cannot set headers, such as the browser
+// EventSource API, pass the access token as ?access_token=. It must run before
+// AuthMiddleware and is meant only for streaming routes.
+func BearerFromQuery(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		if req.Header.Get(echo.HeaderAuthorization) == "" {
+			if token := c.QueryParam("access_token"); token != "" {
+				req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+			}
+		}
+		return next(c)
+	}
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// IPExtractor decides what c.RealIP() returns. The IP is recorded with
+// sessions, so a client must not be able to choose it: without trusted proxies
+// it is the address of the TCP peer and X-Forwarded-For is ignored.
+// trustedProxies is a comma-separated list of CIDRs of the reverse proxies in
+// front of the gateway; only hops from those ranges are skipped in
+// X-Forwarded-For.
+func IPExtractor(trustedProxies string) (echo.IPExtractor, error) {
+	var options []echo.TrustOption
+	for _, cidr := range strings.Split(trustedProxies, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy range %q: %w", cidr, err)
+		}
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+
+	if len(options) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	// Echo trusts loopback and private ranges by default; only the listed ones count
+	options = append(options, echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false))
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIPExtractor(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies string
+		remoteAddr     string
+		forwardedFor   string
+		want           string
+	}{
+		{"no proxies ignores the header", "", "198.51.100.4:5000", "203.0.113.9", "198.51.100.4"},
+		{"private peer is not trusted by default", "", "10.0.0.5:5000", "203.0.113.9", "10.0.0.5"},
+		{"trusted proxy", "10.0.0.0/8", "10.0.0.5:5000", "203.0.113.9", "203.0.113.9"},
+		{"untrusted peer", "10.0.0.0/8", "198.51.100.4:5000", "203.0.113.9", "198.51.100.4"},
+		{"spoofed hop before the proxy", "10.0.0.0/8", "10.0.0.5:5000", "1.2.3.4, 203.0.113.9", "203.0.113.9"},
+		{"chain of trusted proxies", "10.0.0.0/8, 172.16.0.0/12", "10.0.0.5:5000", "203.0.113.9, 172.16.0.2", "203.0.113.9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extract, err := IPExtractor(tt.trustedProxies)
+			if err != nil {
+				t.Fatalf("IPExtractor: %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/login", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			if got := extract(req); got != tt.want {
+				t.Errorf("IP = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIPExtractorRejectsInvalidRange(t *testing.T) {
+	if _, err := IPExtractor("10.0.0.0/8, proxy"); err == nil {
+		t.Error("IPExtractor accepted an invalid range")
+	}
+}
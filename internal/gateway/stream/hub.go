@@ -0,0 +1,221 @@
+// Package stream fans reminder events published by notification-service out
+// to the gateway's Server-Sent Events connections.
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/models"
+	"github.com/redis/go-redis/v9"
+)
+
+const subscriberBuffer = 32
+
+// Subscriber is one open SSE connection.
+type Subscriber struct {
+	userID    uuid.UUID
+	sessionID string // Session of the token the stream was opened with, may be empty
+	events    chan models.PushEvent
+	done      chan struct{} // Closed when the hub drops the subscriber
+	once      sync.Once
+}
+
+func (s *Subscriber) Events() <-chan models.PushEvent {
+	return s.events
+}
+
+// Done is closed if the subscriber could not keep up, in which case the client
+// should reconnect with Last-Event-ID to catch up from the stream history, or
+// if the session it was opened with has been revoked.
+func (s *Subscriber) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *Subscriber) drop() {
+	s.once.Do(func() { close(s.done) })
+}
+
+// Hub holds a single Redis subscription and routes every event only to the
+// connections of the user it belongs to. The same subscription carries
+// session revocations, which close the streams opened with that session.
+type Hub struct {
+	redis *redis.Client
+
+	mu          sync.RWMutex
+	subscribers map[uuid.UUID]map[*Subscriber]struct{}
+}
+
+func NewHub(redisClient *redis.Client) *Hub {
+	return &Hub{
+		redis:       redisClient,
+		subscribers: make(map[uuid.UUID]map[*Subscriber]struct{}),
+	}
+}
+
+func (h *Hub) Start(ctx context.Context) {
+	pubsub := h.redis.Subscribe(ctx, models.PushChannel, models.SessionRevokedChannel)
+	defer pubsub.Close()
+	defer h.dropAll() // Lets open streams finish so the HTTP server can shut down
+
+	slog.Info("SSE hub started", "channels", []string{models.PushChannel, models.SessionRevokedChannel})
+
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Stopping SSE hub...")
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			if msg.Channel == models.SessionRevokedChannel {
+				h.revokeSession(msg.Payload)
+				continue
+			}
+			var event models.PushEvent
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				slog.Error("Failed to unmarshal push event", "error", err)
+				continue
+			}
+			h.publish(event)
+		}
+	}
+}
+
+func (h *Hub) publish(event models.PushEvent) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.subscribers[event.UserID] {
+		select {
+		case sub.events <- event:
+		default:
+			slog.Warn("SSE subscriber is too slow, dropping connection", "user_id", event.UserID)
+			sub.drop()
+		}
+	}
+}
+
+// revokeSession drops every stream opened with the session.
+func (h *Hub) revokeSession(sessionID string) {
+	if sessionID == "" {
+		return
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for _, subs := range h.subscribers {
+		for sub := range subs {
+			if sub.sessionID == sessionID {
+				slog.Info("Session revoked, closing SSE connection", "user_id", sub.userID, "session_id", sessionID)
+				sub.drop()
+			}
+		}
+	}
+}
+
+func (h *Hub) dropAll() {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for _, subs := range h.subscribers {
+		for sub := range subs {
+			sub.drop()
+		}
+	}
+}
+
+// Subscribe registers a stream of the user's events. sessionID is the session
+// of the token it was opened with; revoking that session closes the stream.
+func (h *Hub) Subscribe(userID uuid.UUID, sessionID string) *Subscriber {
+	sub := &Subscriber{
+		userID:    userID,
+		sessionID: sessionID,
+		events:    make(chan models.PushEvent, subscriberBuffer),
+		done:      make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[*Subscriber]struct{})
+	}
+	h.subscribers[userID][sub] = struct{}{}
+	return sub
+}
+
+func (h *Hub) Unsubscribe(sub *Subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subscribers[sub.userID], sub)
+	if len(h.subscribers[sub.userID]) == 0 {
+		delete(h.subscribers, sub.userID)
+	}
+}
+
+// Replay returns the user's events that came after lastEventID, oldest first.
+func (h *Hub) Replay(ctx context.Context, userID uuid.UUID, lastEventID string) ([]models.PushEvent, error) {
+	entries, err := h.redis.XRange(ctx, models.PushStreamKey(userID), "("+lastEventID, "+").Result()
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]models.PushEvent, 0, len(entries))
+	for _, entry := range entries {
+		data, _ := entry.Values["data"].(string)
+		var event models.PushEvent
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			slog.Warn("Skipping malformed push event", "id", entry.ID, "error", err)
+			continue
+		}
+		event.ID = entry.ID
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// ValidEventID reports whether id is a Redis stream ID ("<ms>-<seq>").
+func ValidEventID(id string) bool {
+	_, _, ok := parseEventID(id)
+	return ok
+}
+
+// After reports whether stream ID a is newer than b. Invalid IDs are never newer.
+func After(a, b string) bool {
+	aMs, aSeq, ok := parseEventID(a)
+	if !ok {
+		return false
+	}
+	bMs, bSeq, ok := parseEventID(b)
+	if !ok {
+		return true
+	}
+	if aMs != bMs {
+		return aMs > bMs
+	}
+	return aSeq > bSeq
+}
+
+func parseEventID(id string) (uint64, uint64, bool) {
+	msPart, seqPart, found := strings.Cut(id, "-")
+	if !found {
+		return 0, 0, false
+	}
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	seq, err := strconv.ParseUint(seqPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return ms, seq, true
+}
@@ -0,0 +1,31 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestRevokeSessionDropsOnlyItsStreams(t *testing.T) {
+	h := NewHub(nil)
+	userID := uuid.New()
+	revoked := h.Subscribe(userID, "session-a")
+	other := h.Subscribe(userID, "session-b")
+	legacy := h.Subscribe(userID, "")
+
+	h.revokeSession("session-a")
+	h.revokeSession("")
+
+	select {
+	case <-revoked.Done():
+	default:
+		t.Error("stream of the revoked session is still open")
+	}
+	for name, sub := range map[string]*Subscriber{"other session": other, "no session": legacy} {
+		select {
+		case <-sub.Done():
+			t.Errorf("stream with %s was closed", name)
+		default:
+		}
+	}
+}
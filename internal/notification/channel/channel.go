@@ -0,0 +1,68 @@
+package channel
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message is the channel-independent view of a reminder handed to a channel.
+type Message struct {
+	ReminderID  uuid.UUID
+	UserID      uuid.UUID
+	Title       string
+	Description string
+	RemindAt    time.Time
+	Address     string // Channel-specific recipient (email, URL, ...); empty to use the channel default
+}
+
+// Channel delivers a reminder through one mechanism (log, email, webhook, ...).
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// Registry holds the channels enabled for this notification-service instance.
+type Registry struct {
+	mu       sync.RWMutex
+	channels map[string]Channel
+}
+
+func NewRegistry() *Registry {
+	return &Registry{channels: make(map[string]Channel)}
+}
+
+// Register adds a channel, replacing any previous one with the same name.
+func (r *Registry) Register(ch Channel) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.channels[ch.Name()] = ch
+}
+
+func (r *Registry) Get(name string) (Channel, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ch, ok := r.channels[name]
+	return ch, ok
+}
+
+// All returns registered channels ordered by name.
+func (r *Registry) All() []Channel {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.channels))
+	for name := range r.channels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	channels := make([]Channel, 0, len(names))
+	for _, name := range names {
+		channels = append(channels, r.channels[name])
+	}
+	return channels
+}
@@ -0,0 +1,162 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strings"
+	"text/template"
+	"time"
+)
+
+type SMTPConfig struct {
+	Host             string
+	Port             string
+	Username         string // Empty disables AUTH
+	Password         string
+	From             string
+	DefaultRecipient string // Used when the message carries no address
+	Timeout          time.Duration
+}
+
+var (
+	subjectTemplate = template.Must(template.New("subject").Parse(`Reminder: {{.Title}}`))
+	bodyTemplate    = template.Must(template.New("body").Parse(`{{.Title}}
+{{if .Description}}
+{{.Description}}
+{{end}}
+Scheduled for {{.RemindAt.Format "2006-01-02 15:04 MST"}}
+`))
+)
+
+// EmailChannel delivers reminders over SMTP, upgrading to STARTTLS when offered.
+type EmailChannel struct {
+	config SMTPConfig
+}
+
+func NewEmailChannel(config SMTPConfig) *EmailChannel {
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+	return &EmailChannel{config: config}
+}
+
+func (c *EmailChannel) Name() string {
+	return "email"
+}
+
+func (c *EmailChannel) Send(ctx context.Context, msg Message) error {
+	to := msg.Address
+	if to == "" {
+		to = c.config.DefaultRecipient
+	}
+	if to == "" {
+		return errors.New("email: no recipient address")
+	}
+
+	data, err := c.render(msg, to)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(c.config.Host, c.config.Port)
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("email: failed to connect to %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, c.config.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("email: handshake failed: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: c.config.Host}); err != nil {
+			return fmt.Errorf("email: starttls failed: %w", err)
+		}
+	}
+
+	if c.config.Username != "" {
+		auth := smtp.PlainAuth("", c.config.Username, c.config.Password, c.config.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("email: auth failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(c.config.From); err != nil {
+		return fmt.Errorf("email: MAIL FROM rejected: %w", err)
+	}
+	if err := client.Rcpt(to); err != nil {
+		return fmt.Errorf("email: RCPT TO rejected: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("email: DATA rejected: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return fmt.Errorf("email: failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("email: message rejected: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// render builds an RFC 5322 message with a quoted-printable UTF-8 body.
+func (c *EmailChannel) render(msg Message, to string) ([]byte, error) {
+	var subject, body bytes.Buffer
+	if err := subjectTemplate.Execute(&subject, msg); err != nil {
+		return nil, fmt.Errorf("email: failed to render subject: %w", err)
+	}
+	if err := bodyTemplate.Execute(&body, msg); err != nil {
+		return nil, fmt.Errorf("email: failed to render body: %w", err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", c.config.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", to)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject.String()))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Message-ID: <%s@%s>\r\n", randomID(), c.config.Host)
+	fmt.Fprintf(&buf, "X-Reminder-ID: %s\r\n", msg.ReminderID)
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	buf.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	if _, err := qp.Write([]byte(strings.ReplaceAll(body.String(), "\n", "\r\n"))); err != nil {
+		return nil, fmt.Errorf("email: failed to encode body: %w", err)
+	}
+	if err := qp.Close(); err != nil {
+		return nil, fmt.Errorf("email: failed to encode body: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func randomID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,106 @@
+package channel
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/internal/notification/channel/smtptest"
+)
+
+func TestEmailChannelSend(t *testing.T) {
+	server, err := smtptest.NewServer()
+	if err != nil {
+		t.Fatalf("start smtp server: %v", err)
+	}
+	defer server.Close()
+
+	ch := NewEmailChannel(SMTPConfig{
+		Host: server.Host(),
+		Port: server.Port(),
+		From: "reminders@example.com",
+	})
+
+	msg := Message{
+		ReminderID:  uuid.New(),
+		UserID:      uuid.New(),
+		Title:       "Позвонить маме",
+		Description: "Не забыть про день рождения",
+		RemindAt:    time.Date(2026, 1, 25, 10, 0, 0, 0, time.UTC),
+		Address:     "user@example.com",
+	}
+	if err := ch.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	received := server.Messages()
+	if len(received) != 1 {
+		t.Fatalf("server received %d messages, want 1", len(received))
+	}
+	got := received[0]
+	if got.From != "reminders@example.com" {
+		t.Errorf("MAIL FROM = %q, want reminders@example.com", got.From)
+	}
+	if len(got.To) != 1 || got.To[0] != "user@example.com" {
+		t.Errorf("RCPT TO = %v, want [user@example.com]", got.To)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(got.Data))
+	if err != nil {
+		t.Fatalf("parse message: %v", err)
+	}
+	if to := parsed.Header.Get("To"); to != "user@example.com" {
+		t.Errorf("To header = %q, want user@example.com", to)
+	}
+	if id := parsed.Header.Get("X-Reminder-ID"); id != msg.ReminderID.String() {
+		t.Errorf("X-Reminder-ID = %q, want %s", id, msg.ReminderID)
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("decode subject: %v", err)
+	}
+	if subject != "Reminder: Позвонить маме" {
+		t.Errorf("Subject = %q, want %q", subject, "Reminder: Позвонить маме")
+	}
+
+	body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	if err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	for _, want := range []string{msg.Title, msg.Description, "Scheduled for 2026-01-25 10:00 UTC"} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("body %q does not contain %q", body, want)
+		}
+	}
+}
+
+func TestEmailChannelDefaultRecipient(t *testing.T) {
+	server, err := smtptest.NewServer()
+	if err != nil {
+		t.Fatalf("start smtp server: %v", err)
+	}
+	defer server.Close()
+
+	ch := NewEmailChannel(SMTPConfig{
+		Host:             server.Host(),
+		Port:             server.Port(),
+		From:             "reminders@example.com",
+		DefaultRecipient: "ops@example.com",
+	})
+
+	if err := ch.Send(context.Background(), Message{ReminderID: uuid.New(), Title: "Backup"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	received := server.Messages()
+	if len(received) != 1 || len(received[0].To) != 1 || received[0].To[0] != "ops@example.com" {
+		t.Fatalf("received %+v, want one message to ops@example.com", received)
+	}
+}
@@ -0,0 +1,25 @@
+package channel
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogChannel writes reminders to the service log. Useful locally and as a fallback.
+type LogChannel struct{}
+
+func NewLogChannel() *LogChannel {
+	return &LogChannel{}
+}
+
+func (c *LogChannel) Name() string {
+	return "log"
+}
+
+func (c *LogChannel) Send(ctx context.Context, msg Message) error {
+	slog.InfoContext(ctx, "[NOTIFICATION] Sending reminder",
+		"user_id", msg.UserID,
+		"title", msg.Title,
+		"desc", msg.Description)
+	return nil
+}
@@ -0,0 +1,110 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress is returned for webhook targets inside the cluster or on
+// the host: loopback, link-local (including cloud metadata), private and
+// other non-public ranges.
+var ErrForbiddenAddress = errors.New("webhook target is not a public address")
+
+// nonPublicPrefixes lists ranges netip.Addr has no predicate for.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),   // reserved, includes broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, may embed any IPv4 address
+}
+
+// IsPublicAddress reports whether a webhook may be delivered to ip.
+func IsPublicAddress(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsValid() || ip.IsUnspecified() || ip.IsLoopback() || ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckWebhookURL validates a webhook URL at registration: http(s) only, and
+// the host must resolve to public addresses only. DNS can change afterwards,
+// so the webhook client checks every connection again at dial time.
+func CheckWebhookURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return errors.New("invalid url")
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return errors.New("url scheme must be http or https")
+	}
+
+	host := u.Hostname()
+	if strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".localhost") {
+		return ErrForbiddenAddress
+	}
+
+	if ip, err := netip.ParseAddr(host); err == nil {
+		if !IsPublicAddress(ip) {
+			return ErrForbiddenAddress
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	ips, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("cannot resolve url host: %s", host)
+	}
+	for _, ip := range ips {
+		if !IsPublicAddress(ip) {
+			return ErrForbiddenAddress
+		}
+	}
+	return nil
+}
+
+// guardedDialControl refuses connections to non-public addresses. It runs
+// after DNS resolution, for every connection including redirects.
+func guardedDialControl(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, address)
+	}
+	if !IsPublicAddress(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, address)
+	}
+	return nil
+}
+
+// newGuardedClient returns an HTTP client that can only reach public addresses.
+// Proxies are disabled, as the proxy would make the connection on our behalf.
+func newGuardedClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: guardedDialControl,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
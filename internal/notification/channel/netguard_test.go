@@ -0,0 +1,86 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestIsPublicAddress(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::ffff:127.0.0.1", false},
+		{"255.255.255.255", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsPublicAddress(netip.MustParseAddr(tt.ip)); got != tt.want {
+			t.Errorf("IsPublicAddress(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestCheckWebhookURL(t *testing.T) {
+	tests := []struct {
+		url       string
+		forbidden bool
+	}{
+		{"http://169.254.169.254/latest/meta-data/", true},
+		{"http://localhost:8080/hook", true},
+		{"http://api.localhost/hook", true},
+		{"http://127.0.0.1/hook", true},
+		{"http://[::1]/hook", true},
+		{"https://10.0.0.5/hook", true},
+		{"https://192.168.0.10:8443/hook", true},
+		{"https://93.184.216.34/hook", false},
+	}
+
+	for _, tt := range tests {
+		err := CheckWebhookURL(context.Background(), tt.url)
+		if got := errors.Is(err, ErrForbiddenAddress); got != tt.forbidden {
+			t.Errorf("CheckWebhookURL(%s) = %v, want forbidden=%v", tt.url, err, tt.forbidden)
+		}
+	}
+
+	if err := CheckWebhookURL(context.Background(), "ftp://93.184.216.34/"); err == nil {
+		t.Error("CheckWebhookURL accepted an ftp url")
+	}
+}
+
+// A webhook registered with a public name may later resolve to an internal
+// address; the dial-time check must still refuse it.
+func TestWebhookClientRefusesLoopback(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+	}))
+	defer server.Close()
+
+	ch := NewWebhookChannel(staticWebhooks{{ID: uuid.New(), URL: server.URL, Secret: "s", Enabled: true}}, nil, WebhookConfig{MaxAttempts: 1})
+	err := ch.Send(context.Background(), Message{ReminderID: uuid.New(), UserID: uuid.New()})
+	if !errors.Is(err, ErrForbiddenAddress) {
+		t.Fatalf("Send = %v, want ErrForbiddenAddress", err)
+	}
+	if hits != 0 {
+		t.Errorf("server received %d requests, want 0", hits)
+	}
+}
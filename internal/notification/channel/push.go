@@ -0,0 +1,86 @@
+package channel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kiribu/jwt-practice/models"
+	"github.com/redis/go-redis/v9"
+)
+
+type PushConfig struct {
+	HistorySize int64         // Events kept per user for Last-Event-ID replay
+	HistoryTTL  time.Duration // Idle users' streams expire after this
+}
+
+// PushChannel hands reminders to the API gateway, which streams them to the
+// user's browsers over SSE. Events are appended to a per-user Redis stream for
+// replay and published on models.PushChannel for live delivery.
+type PushChannel struct {
+	redis  *redis.Client
+	config PushConfig
+}
+
+func NewPushChannel(redisClient *redis.Client, config PushConfig) *PushChannel {
+	if config.HistorySize <= 0 {
+		config.HistorySize = 100
+	}
+	if config.HistoryTTL <= 0 {
+		config.HistoryTTL = 24 * time.Hour
+	}
+
+	return &PushChannel{
+		redis:  redisClient,
+		config: config,
+	}
+}
+
+func (c *PushChannel) Name() string {
+	return "push"
+}
+
+func (c *PushChannel) Send(ctx context.Context, msg Message) error {
+	event := models.PushEvent{
+		Type:        models.PushEventReminderDelivered,
+		ReminderID:  msg.ReminderID,
+		UserID:      msg.UserID,
+		Title:       msg.Title,
+		Description: msg.Description,
+		RemindAt:    msg.RemindAt,
+		Timestamp:   time.Now(),
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("push: failed to marshal event: %w", err)
+	}
+
+	key := models.PushStreamKey(msg.UserID)
+	id, err := c.redis.XAdd(ctx, &redis.XAddArgs{
+		Stream: key,
+		MaxLen: c.config.HistorySize,
+		Approx: true,
+		Values: map[string]any{"data": data},
+	}).Result()
+	if err != nil {
+		return fmt.Errorf("push: failed to append event: %w", err)
+	}
+
+	event.ID = id
+	live, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("push: failed to marshal event: %w", err)
+	}
+
+	_, err = c.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Expire(ctx, key, c.config.HistoryTTL)
+		pipe.Publish(ctx, models.PushChannel, live)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("push: failed to publish event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/models"
+)
+
+const (
+	// SignatureHeader carries "t=<unix seconds>,v1=<hex HMAC-SHA256>" where the
+	// MAC is computed over "<t>.<body>" with the webhook secret.
+	SignatureHeader = "X-Reminder-Signature"
+	EventIDHeader   = "X-Reminder-Event-ID" // Same for all retries and redeliveries, lets receivers deduplicate
+)
+
+// ErrWebhookGone is returned by Redeliver when the endpoint was deleted or
+// disabled since the delivery failed.
+var ErrWebhookGone = errors.New("webhook: endpoint no longer enabled")
+
+// WebhookSource returns the endpoints a user wants reminders pushed to.
+type WebhookSource interface {
+	GetEnabledWebhooks(ctx context.Context, userID uuid.UUID) ([]models.Webhook, error)
+}
+
+// WebhookRetryQueue persists failed deliveries for the retry worker, so a dead
+// endpoint never holds up the Kafka partition the reminder arrived on.
+type WebhookRetryQueue interface {
+	CreateWebhookRetry(ctx context.Context, retry *models.WebhookRetry) error
+}
+
+type WebhookConfig struct {
+	MaxAttempts    int           // Total attempts per endpoint, including the first
+	InitialBackoff time.Duration // Delay before the first retry, doubled after each attempt
+	MaxBackoff     time.Duration
+	Timeout        time.Duration // Per-request timeout
+	Client         *http.Client  // Nil builds one that only reaches public addresses
+}
+
+// WebhookPayload is the JSON body POSTed to user endpoints.
+type WebhookPayload struct {
+	ID          uuid.UUID `json:"id"`
+	Type        string    `json:"type"`
+	ReminderID  uuid.UUID `json:"reminder_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	RemindAt    time.Time `json:"remind_at"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+type WebhookChannel struct {
+	source  WebhookSource
+	retries WebhookRetryQueue
+	client  *http.Client
+	config  WebhookConfig
+}
+
+func NewWebhookChannel(source WebhookSource, retries WebhookRetryQueue, config WebhookConfig) *WebhookChannel {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 5
+	}
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = time.Second
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = 30 * time.Second
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+
+	client := config.Client
+	if client == nil {
+		client = newGuardedClient(config.Timeout)
+	}
+
+	return &WebhookChannel{
+		source:  source,
+		retries: retries,
+		client:  client,
+		config:  config,
+	}
+}
+
+func (c *WebhookChannel) Name() string {
+	return "webhook"
+}
+
+// Send posts the reminder once to every enabled endpoint of the user. It fails
+// if any endpoint could not be reached; those endpoints are queued for the
+// retry worker instead of being retried here. Users without endpoints have
+// nothing to deliver, which is not a failure.
+func (c *WebhookChannel) Send(ctx context.Context, msg Message) error {
+	webhooks, err := c.source.GetEnabledWebhooks(ctx, msg.UserID)
+	if err != nil {
+		return fmt.Errorf("webhook: failed to load endpoints: %w", err)
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	eventID := EventID(msg)
+	body, err := json.Marshal(WebhookPayload{
+		ID:          eventID,
+		Type:        "reminder.fired",
+		ReminderID:  msg.ReminderID,
+		UserID:      msg.UserID,
+		Title:       msg.Title,
+		Description: msg.Description,
+		RemindAt:    msg.RemindAt,
+		Timestamp:   time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("webhook: failed to marshal payload: %w", err)
+	}
+
+	var errs []error
+	for _, webhook := range webhooks {
+		if err := c.post(ctx, webhook, eventID, body); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", webhook.URL, c.queueRetry(ctx, webhook, msg, eventID, body, err)))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("webhook: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// queueRetry stores the failed first attempt for the retry worker and returns
+// err annotated with what happens next.
+func (c *WebhookChannel) queueRetry(ctx context.Context, webhook models.Webhook, msg Message, eventID uuid.UUID, body []byte, err error) error {
+	if c.config.MaxAttempts <= 1 || errors.Is(err, ErrForbiddenAddress) {
+		return err
+	}
+
+	retry := &models.WebhookRetry{
+		WebhookID:     webhook.ID,
+		ReminderID:    msg.ReminderID,
+		UserID:        msg.UserID,
+		EventID:       eventID,
+		Payload:       body,
+		Attempts:      1,
+		LastError:     err.Error(),
+		NextAttemptAt: time.Now().Add(c.Backoff(1)),
+	}
+	if qerr := c.retries.CreateWebhookRetry(ctx, retry); qerr != nil {
+		slog.ErrorContext(ctx, "Failed to queue webhook retry", "webhook_id", webhook.ID, "error", qerr)
+		return err
+	}
+	return fmt.Errorf("%w (retry 2/%d at %s)", err, c.config.MaxAttempts, retry.NextAttemptAt.Format(time.RFC3339))
+}
+
+// Redeliver makes the next attempt of a queued retry with the original event
+// ID and body, signed with the endpoint's current secret.
+func (c *WebhookChannel) Redeliver(ctx context.Context, retry models.WebhookRetry) error {
+	webhooks, err := c.source.GetEnabledWebhooks(ctx, retry.UserID)
+	if err != nil {
+		return fmt.Errorf("webhook: failed to load endpoints: %w", err)
+	}
+	for _, webhook := range webhooks {
+		if webhook.ID == retry.WebhookID {
+			return c.post(ctx, webhook, retry.EventID, retry.Payload)
+		}
+	}
+	return ErrWebhookGone
+}
+
+// MaxAttempts is the total number of attempts per endpoint, including the first.
+func (c *WebhookChannel) MaxAttempts() int {
+	return c.config.MaxAttempts
+}
+
+// Backoff returns the delay after the given failed attempt (1-based).
+func (c *WebhookChannel) Backoff(attempt int) time.Duration {
+	backoff := c.config.InitialBackoff
+	for i := 1; i < attempt && backoff < c.config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, c.config.MaxBackoff)
+}
+
+func (c *WebhookChannel) post(ctx context.Context, webhook models.Webhook, eventID uuid.UUID, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	// The signature is computed per attempt so receivers can reject stale timestamps.
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, "t="+timestamp+",v1="+Sign(webhook.Secret, timestamp, body))
+	req.Header.Set(EventIDHeader, eventID.String())
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// webhookEventNamespace scopes the name-based UUIDs of EventID.
+var webhookEventNamespace = uuid.MustParse("6f0c7a3e-2b9d-4c51-9e0a-8d4f1b7c2e63")
+
+// EventID identifies one firing of a reminder: the reminder and the occurrence
+// it fired for. Kafka redeliveries and quiet-hours releases of the same firing
+// get the same ID, so receivers can deduplicate on EventIDHeader.
+func EventID(msg Message) uuid.UUID {
+	return uuid.NewSHA1(webhookEventNamespace, []byte(msg.ReminderID.String()+"@"+msg.RemindAt.UTC().Format(time.RFC3339Nano)))
+}
+
+// Sign returns the hex HMAC-SHA256 of "<timestamp>.<body>". Receivers use the
+// same function to verify the SignatureHeader.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
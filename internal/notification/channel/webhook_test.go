@@ -0,0 +1,124 @@
+package channel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/models"
+)
+
+type staticWebhooks []models.Webhook
+
+func (s staticWebhooks) GetEnabledWebhooks(ctx context.Context, userID uuid.UUID) ([]models.Webhook, error) {
+	return s, nil
+}
+
+type retryRecorder struct {
+	retries []models.WebhookRetry
+}
+
+func (r *retryRecorder) CreateWebhookRetry(ctx context.Context, retry *models.WebhookRetry) error {
+	r.retries = append(r.retries, *retry)
+	return nil
+}
+
+func TestWebhookEventIDIsStableAcrossSends(t *testing.T) {
+	var mu sync.Mutex
+	var ids []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		ids = append(ids, r.Header.Get(EventIDHeader))
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	// The test server listens on loopback, which the default client refuses
+	ch := NewWebhookChannel(staticWebhooks{{ID: uuid.New(), URL: server.URL, Secret: "s", Enabled: true}}, &retryRecorder{}, WebhookConfig{Client: server.Client()})
+	msg := Message{ReminderID: uuid.New(), UserID: uuid.New(), Title: "t", RemindAt: time.Now()}
+
+	// A Kafka redelivery hands the channel the same reminder again
+	for range 2 {
+		if err := ch.Send(context.Background(), msg); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+
+	if len(ids) != 2 || ids[0] == "" || ids[0] != ids[1] {
+		t.Fatalf("event IDs = %v, want the same ID twice", ids)
+	}
+	if ids[0] != EventID(msg).String() {
+		t.Errorf("event ID = %s, want %s", ids[0], EventID(msg))
+	}
+
+	next := msg
+	next.RemindAt = msg.RemindAt.Add(24 * time.Hour)
+	if EventID(next) == EventID(msg) {
+		t.Error("the next occurrence got the same event ID")
+	}
+}
+
+func TestWebhookSendWithoutEndpoints(t *testing.T) {
+	ch := NewWebhookChannel(staticWebhooks{}, &retryRecorder{}, WebhookConfig{})
+	if err := ch.Send(context.Background(), Message{ReminderID: uuid.New(), UserID: uuid.New()}); err != nil {
+		t.Fatalf("Send without endpoints = %v, want nil", err)
+	}
+}
+
+// A failing endpoint is attempted once and queued; Send must not sleep
+// through the backoff on the consumer's goroutine.
+func TestWebhookSendQueuesRetry(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	webhook := models.Webhook{ID: uuid.New(), URL: server.URL, Secret: "s", Enabled: true}
+	queue := &retryRecorder{}
+	ch := NewWebhookChannel(staticWebhooks{webhook}, queue, WebhookConfig{
+		MaxAttempts:    5,
+		InitialBackoff: time.Minute,
+		MaxBackoff:     time.Hour,
+		Client:         server.Client(),
+	})
+	msg := Message{ReminderID: uuid.New(), UserID: uuid.New(), Title: "t", RemindAt: time.Now()}
+
+	start := time.Now()
+	if err := ch.Send(context.Background(), msg); err == nil {
+		t.Fatal("Send to a failing endpoint succeeded, want error")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Send took %s, want a single attempt", elapsed)
+	}
+	if hits != 1 {
+		t.Errorf("endpoint hit %d times, want 1", hits)
+	}
+
+	if len(queue.retries) != 1 {
+		t.Fatalf("queued %d retries, want 1", len(queue.retries))
+	}
+	retry := queue.retries[0]
+	if retry.WebhookID != webhook.ID || retry.EventID != EventID(msg) || retry.Attempts != 1 {
+		t.Errorf("queued retry = %+v, want webhook %s, event %s, 1 attempt", retry, webhook.ID, EventID(msg))
+	}
+	if retry.NextAttemptAt.Before(start.Add(time.Minute)) {
+		t.Errorf("next attempt at %s, want after the initial backoff", retry.NextAttemptAt)
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	ch := NewWebhookChannel(staticWebhooks{}, nil, WebhookConfig{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second})
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		if got := ch.Backoff(i + 1); got != w {
+			t.Errorf("Backoff(%d) = %s, want %s", i+1, got, w)
+		}
+	}
+}
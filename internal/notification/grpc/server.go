@@ -0,0 +1,209 @@
+package notificationgrpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/internal/notification/grpc/pb"
+	"github.com/kiribu/jwt-practice/internal/notification/service"
+	"github.com/kiribu/jwt-practice/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type NotificationServer struct {
+	pb.UnimplementedNotificationServiceServer
+	service *service.NotificationService
+}
+
+func NewNotificationServer(svc *service.NotificationService) *NotificationServer {
+	return &NotificationServer{service: svc}
+}
+
+func (s *NotificationServer) CreateWebhook(ctx context.Context, req *pb.CreateWebhookRequest) (*pb.WebhookResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
+	}
+
+	webhook, err := s.service.CreateWebhook(ctx, userID, req.Url)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return toProtoWebhook(webhook, true), nil
+}
+
+func (s *NotificationServer) ListWebhooks(ctx context.Context, req *pb.ListWebhooksRequest) (*pb.ListWebhooksResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
+	}
+
+	webhooks, err := s.service.ListWebhooks(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	var protoWebhooks []*pb.WebhookResponse
+	for _, w := range webhooks {
+		protoWebhooks = append(protoWebhooks, toProtoWebhook(&w, false))
+	}
+
+	return &pb.ListWebhooksResponse{Webhooks: protoWebhooks}, nil
+}
+
+func (s *NotificationServer) GetWebhook(ctx context.Context, req *pb.GetWebhookRequest) (*pb.WebhookResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
+	}
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid id: %v", err)
+	}
+
+	webhook, err := s.service.GetWebhook(ctx, userID, id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return toProtoWebhook(webhook, false), nil
+}
+
+func (s *NotificationServer) UpdateWebhook(ctx context.Context, req *pb.UpdateWebhookRequest) (*pb.WebhookResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
+	}
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid id: %v", err)
+	}
+
+	webhook, err := s.service.UpdateWebhook(ctx, userID, id, req.Url, req.Enabled)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return toProtoWebhook(webhook, false), nil
+}
+
+func (s *NotificationServer) RotateWebhookSecret(ctx context.Context, req *pb.RotateWebhookSecretRequest) (*pb.WebhookResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
+	}
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid id: %v", err)
+	}
+
+	webhook, err := s.service.RotateWebhookSecret(ctx, userID, id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return toProtoWebhook(webhook, true), nil
+}
+
+func (s *NotificationServer) DeleteWebhook(ctx context.Context, req *pb.DeleteWebhookRequest) (*pb.DeleteWebhookResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return &pb.DeleteWebhookResponse{
+			Success: false,
+			Message: "invalid user_id: " + err.Error(),
+		}, nil
+	}
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return &pb.DeleteWebhookResponse{
+			Success: false,
+			Message: "invalid id: " + err.Error(),
+		}, nil
+	}
+
+	if err := s.service.DeleteWebhook(ctx, userID, id); err != nil {
+		return &pb.DeleteWebhookResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.DeleteWebhookResponse{
+		Success: true,
+		Message: "Webhook deleted successfully",
+	}, nil
+}
+
+func (s *NotificationServer) GetPreferences(ctx context.Context, req *pb.GetPreferencesRequest) (*pb.PreferencesResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
+	}
+
+	prefs, err := s.service.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return toProtoPreferences(prefs), nil
+}
+
+func (s *NotificationServer) UpdatePreferences(ctx context.Context, req *pb.UpdatePreferencesRequest) (*pb.PreferencesResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
+	}
+
+	channels := make([]models.ChannelPreference, 0, len(req.Channels))
+	for _, ch := range req.Channels {
+		channels = append(channels, models.ChannelPreference{
+			Channel: ch.Channel,
+			Address: ch.Address,
+		})
+	}
+
+	prefs, err := s.service.UpdatePreferences(ctx, userID, channels, req.QuietHoursStart, req.QuietHoursEnd, req.Timezone)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return toProtoPreferences(prefs), nil
+}
+
+// toProtoWebhook only exposes the signing secret right after it was generated.
+func toProtoWebhook(w *models.Webhook, withSecret bool) *pb.WebhookResponse {
+	resp := &pb.WebhookResponse{
+		Id:        w.ID.String(),
+		UserId:    w.UserID.String(),
+		Url:       w.URL,
+		Enabled:   w.Enabled,
+		CreatedAt: w.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt: w.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if withSecret {
+		resp.Secret = w.Secret
+	}
+	return resp
+}
+
+func toProtoPreferences(p *models.NotificationPreferences) *pb.PreferencesResponse {
+	resp := &pb.PreferencesResponse{
+		UserId:          p.UserID.String(),
+		Channels:        []*pb.ChannelPreference{},
+		QuietHoursStart: p.QuietHoursStart,
+		QuietHoursEnd:   p.QuietHoursEnd,
+		Timezone:        p.Timezone,
+	}
+	for _, ch := range p.Channels {
+		resp.Channels = append(resp.Channels, &pb.ChannelPreference{
+			Channel: ch.Channel,
+			Address: ch.Address,
+		})
+	}
+	if !p.UpdatedAt.IsZero() {
+		resp.UpdatedAt = p.UpdatedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return resp
+}
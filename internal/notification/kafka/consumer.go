@@ -3,17 +3,25 @@ package kafka
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 
+	"github.com/kiribu/jwt-practice/internal/notification/service"
 	"github.com/kiribu/jwt-practice/models"
+	"github.com/kiribu/jwt-practice/pkg/correlation"
+	"github.com/kiribu/jwt-practice/pkg/events"
+	"github.com/kiribu/jwt-practice/pkg/metrics"
+	"github.com/kiribu/jwt-practice/pkg/tracing"
 	"github.com/segmentio/kafka-go"
 )
 
 type Consumer struct {
-	reader *kafka.Reader
+	reader     *kafka.Reader
+	dispatcher *service.Dispatcher
 }
 
-func NewConsumer(brokers []string, topic, groupID string) *Consumer {
+func NewConsumer(brokers []string, topic, groupID string, dispatcher *service.Dispatcher) *Consumer {
 	reader := kafka.NewReader(kafka.ReaderConfig{
 		Brokers:     brokers,
 		Topic:       topic,
@@ -23,7 +31,10 @@ func NewConsumer(brokers []string, topic, groupID string) *Consumer {
 		StartOffset: kafka.FirstOffset,
 	})
 
-	return &Consumer{reader: reader}
+	return &Consumer{
+		reader:     reader,
+		dispatcher: dispatcher,
+	}
 }
 
 func (c *Consumer) Start(ctx context.Context) {
@@ -51,19 +62,75 @@ func (c *Consumer) processMessage(ctx context.Context) {
 		return
 	}
 
-	c.handlePayload(m.Value)
-	c.reader.CommitMessages(ctx, m)
+	// Committing a trigger whose receipt was not published would lose the receipt
+	if err := c.handleMessage(ctx, m); err != nil {
+		slog.Error("Leaving message uncommitted", "partition", m.Partition, "offset", m.Offset, "error", err)
+		return
+	}
+	if err := c.reader.CommitMessages(ctx, m); err != nil {
+		slog.Error("Error committing message", "error", err, "partition", m.Partition, "offset", m.Offset)
+		metrics.ConsumerError(m, c.reader.Config().GroupID, metrics.ReasonCommit)
+	}
+}
+
+// handleMessage dispatches one trigger. It returns an error only when the
+// trigger must not be committed; malformed and unsupported triggers are logged
+// and skipped.
+func (c *Consumer) handleMessage(ctx context.Context, m kafka.Message) (err error) {
+	group := c.reader.Config().GroupID
+	metrics.ObserveFetch(m, group)
+
+	ctx = correlation.FromKafka(ctx, m.Headers)
+	ctx, span := tracing.StartProcess(ctx, m, group)
+	defer func() { tracing.End(span, err) }()
+
+	reminder, err := decodeTrigger(m)
+	if errors.Is(err, models.ErrUnsupportedEvent) {
+		// Newer producers may emit versions this build does not understand; skip them explicitly
+		slog.WarnContext(ctx, "Rejecting unsupported event", "error", err)
+		metrics.ConsumerError(m, group, metrics.ReasonUnsupported)
+		return nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to unmarshal reminder", "error", err, "partition", m.Partition, "offset", m.Offset)
+		metrics.ConsumerError(m, group, metrics.ReasonDecode)
+		return nil
+	}
+
+	if err = c.dispatcher.Dispatch(ctx, reminder); err != nil {
+		metrics.ConsumerError(m, group, metrics.ReasonProcess)
+	}
+	return err
 }
 
-func (c *Consumer) handlePayload(data []byte) {
+// decodeTrigger reads a v1 notification.trigger envelope in whatever encoding
+// the message header names, or a bare JSON Reminder written before envelopes
+// were introduced.
+func decodeTrigger(m kafka.Message) (models.Reminder, error) {
 	var reminder models.Reminder
-	if err := json.Unmarshal(data, &reminder); err != nil {
-		slog.Error("Failed to unmarshal reminder", "error", err, "data", string(data))
-		return
+
+	codec, err := events.CodecFor(m.Headers)
+	if err != nil {
+		return reminder, err
 	}
 
-	slog.Info("[NOTIFICATION] Sending reminder",
-		"user_id", reminder.UserID,
-		"title", reminder.Title,
-		"desc", reminder.Description)
+	envelope, err := codec.Decode(m.Value)
+	if errors.Is(err, models.ErrNoEnvelope) {
+		err = json.Unmarshal(m.Value, &reminder)
+		return reminder, err
+	}
+	if err != nil {
+		return reminder, err
+	}
+
+	switch envelope.Type {
+	case models.EventTypeNotificationTrigger:
+		var trigger models.NotificationTriggerData
+		if err := json.Unmarshal(envelope.Data, &trigger); err != nil {
+			return reminder, fmt.Errorf("failed to unmarshal %s data: %w", envelope.Type, err)
+		}
+		return trigger.Reminder, nil
+	default:
+		return reminder, fmt.Errorf("%w: type %q", models.ErrUnsupportedEvent, envelope.Type)
+	}
 }
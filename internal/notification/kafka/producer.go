@@ -0,0 +1,70 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kiribu/jwt-practice/models"
+	"github.com/kiribu/jwt-practice/pkg/correlation"
+	"github.com/kiribu/jwt-practice/pkg/events"
+	"github.com/kiribu/jwt-practice/pkg/tracing"
+	"github.com/segmentio/kafka-go"
+)
+
+// ResultProducer publishes delivery receipts back to reminder-service.
+type ResultProducer struct {
+	writer *kafka.Writer
+}
+
+func NewResultProducer(brokers []string, topic string) *ResultProducer {
+	writer := &kafka.Writer{
+		Addr:                   kafka.TCP(brokers...),
+		Topic:                  topic,
+		Balancer:               &kafka.Hash{}, // Same key, same partition: per-key ordering
+		AllowAutoTopicCreation: true,
+	}
+
+	return &ResultProducer{writer: writer}
+}
+
+func (p *ResultProducer) Close() error {
+	return p.writer.Close()
+}
+
+// SendResult publishes the receipt as a notification.result envelope in JSON.
+func (p *ResultProducer) SendResult(ctx context.Context, result models.NotificationResult) (err error) {
+	envelope, err := models.NewNotificationResultEnvelope(result)
+	if err != nil {
+		return err
+	}
+	codec := events.JSONCodec{}
+	data, err := codec.Encode(envelope)
+	if err != nil {
+		return fmt.Errorf("failed to marshal result: %w", err)
+	}
+
+	msg := kafka.Message{
+		Key:     []byte(result.ReminderID.String()),
+		Value:   data,
+		Time:    time.Now(),
+		Headers: []kafka.Header{events.Header(codec)},
+	}
+	if id := correlation.FromContext(ctx); id != "" {
+		msg.Headers = append(msg.Headers, kafka.Header{Key: correlation.KafkaHeader, Value: []byte(id)})
+	}
+
+	ctx, span := tracing.StartPublish(ctx, &msg, p.writer.Topic)
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := p.writer.WriteMessages(ctx, msg); err != nil {
+		return fmt.Errorf("failed to write message to kafka: %w", err)
+	}
+
+	slog.DebugContext(ctx, "Sent delivery result to Kafka", "topic", p.writer.Topic, "reminder_id", result.ReminderID, "status", result.Status)
+	return nil
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/internal/notification/channel"
+	"github.com/kiribu/jwt-practice/internal/notification/storage"
+	"github.com/kiribu/jwt-practice/models"
+)
+
+// Results that fail to publish are retried with these delays, so a Kafka
+// outage holds the trigger back instead of losing the receipt.
+const (
+	resultRetryBase = 500 * time.Millisecond
+	resultRetryMax  = 30 * time.Second
+)
+
+// ResultPublisher reports delivery outcomes back to Reminder Service.
+type ResultPublisher interface {
+	SendResult(ctx context.Context, result models.NotificationResult) error
+}
+
+// Dispatcher delivers fired reminders according to the user's preferences.
+type Dispatcher struct {
+	notifications *NotificationService
+	storage       storage.NotificationStorage
+	channels      *channel.Registry
+	results       ResultPublisher
+	retryBase     time.Duration
+	retryMax      time.Duration
+}
+
+func NewDispatcher(notifications *NotificationService, storage storage.NotificationStorage, channels *channel.Registry, results ResultPublisher) *Dispatcher {
+	return &Dispatcher{
+		notifications: notifications,
+		storage:       storage,
+		channels:      channels,
+		results:       results,
+		retryBase:     resultRetryBase,
+		retryMax:      resultRetryMax,
+	}
+}
+
+// Dispatch sends the reminder through the user's enabled channels and publishes
+// one result per channel. During quiet hours the reminder is parked until the
+// window ends instead; if that fails it is delivered right away.
+//
+// Publishing is retried without sending again. Dispatch returns an error only
+// when ctx ends before every result is published; the caller must then keep the
+// reminder so that it is dispatched again.
+func (d *Dispatcher) Dispatch(ctx context.Context, reminder models.Reminder) error {
+	prefs, err := d.notifications.GetPreferences(ctx, reminder.UserID)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to load notification preferences, using defaults", "user_id", reminder.UserID, "error", err)
+		prefs = &models.NotificationPreferences{UserID: reminder.UserID, Timezone: defaultTimezone}
+	}
+
+	if until, quiet := QuietHoursEnd(prefs, time.Now()); quiet {
+		err := d.park(ctx, reminder, until)
+		if err == nil {
+			slog.InfoContext(ctx, "Reminder deferred by quiet hours", "reminder_id", reminder.ID, "deliver_at", until)
+			return nil
+		}
+		slog.ErrorContext(ctx, "Failed to defer reminder, delivering now", "reminder_id", reminder.ID, "error", err)
+	}
+
+	for _, result := range d.deliver(ctx, reminder, prefs) {
+		if err := d.publish(ctx, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// publish retries SendResult with exponential backoff until it succeeds or ctx
+// is done.
+func (d *Dispatcher) publish(ctx context.Context, result models.NotificationResult) error {
+	delay := d.retryBase
+	for attempt := 1; ; attempt++ {
+		err := d.results.SendResult(ctx, result)
+		if err == nil {
+			return nil
+		}
+		slog.WarnContext(ctx, "Retrying delivery result", "reminder_id", result.ReminderID, "channel", result.Channel, "attempt", attempt, "retry_in", delay, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("publish delivery result for reminder %s: %w", result.ReminderID, err)
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, d.retryMax)
+	}
+}
+
+func (d *Dispatcher) park(ctx context.Context, reminder models.Reminder, until time.Time) error {
+	payload, err := json.Marshal(reminder)
+	if err != nil {
+		return err
+	}
+	return d.storage.CreateDeferredDelivery(ctx, &models.DeferredDelivery{
+		ReminderID: reminder.ID,
+		UserID:     reminder.UserID,
+		Payload:    payload,
+		DeliverAt:  until,
+	})
+}
+
+// deliver sends through every channel the user enabled, or every registered
+// channel if the user has no channel preferences.
+func (d *Dispatcher) deliver(ctx context.Context, reminder models.Reminder, prefs *models.NotificationPreferences) []models.NotificationResult {
+	msg := channel.Message{
+		ReminderID:  reminder.ID,
+		UserID:      reminder.UserID,
+		Title:       reminder.Title,
+		Description: reminder.Description,
+		RemindAt:    reminder.RemindAt,
+	}
+
+	targets := prefs.Channels
+	if len(targets) == 0 {
+		for _, ch := range d.channels.All() {
+			targets = append(targets, models.ChannelPreference{Channel: ch.Name()})
+		}
+	}
+
+	var results []models.NotificationResult
+	for _, target := range targets {
+		ch, ok := d.channels.Get(target.Channel)
+		if !ok {
+			slog.WarnContext(ctx, "Channel enabled by user is not available", "user_id", reminder.UserID, "channel", target.Channel)
+			continue
+		}
+
+		result := models.NotificationResult{
+			ResultID:   uuid.Must(uuid.NewV7()),
+			ReminderID: reminder.ID,
+			UserID:     reminder.UserID,
+			Status:     models.DeliveryStatusDelivered,
+			Channel:    ch.Name(),
+		}
+
+		msg.Address = target.Address
+		if err := ch.Send(ctx, msg); err != nil {
+			slog.ErrorContext(ctx, "Failed to deliver reminder", "reminder_id", reminder.ID, "channel", ch.Name(), "error", err)
+			result.Status = models.DeliveryStatusFailed
+			result.Reason = err.Error()
+		}
+
+		result.Timestamp = time.Now()
+		results = append(results, result)
+	}
+
+	return results
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/internal/notification/channel"
+	"github.com/kiribu/jwt-practice/internal/notification/storage"
+	"github.com/kiribu/jwt-practice/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// defaultsStorage has no saved preferences, so every user gets the defaults.
+type defaultsStorage struct {
+	storage.NotificationStorage
+}
+
+func (defaultsStorage) GetPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error) {
+	return nil, storage.ErrPreferencesNotFound
+}
+
+// countingChannel accepts every message and counts them.
+type countingChannel struct {
+	sent int
+}
+
+func (c *countingChannel) Name() string { return "log" }
+
+func (c *countingChannel) Send(ctx context.Context, msg channel.Message) error {
+	c.sent++
+	return nil
+}
+
+// flakyPublisher fails the first failures calls, or every call if failures
+// is negative.
+type flakyPublisher struct {
+	failures  int
+	calls     int
+	published []models.NotificationResult
+}
+
+func (p *flakyPublisher) SendResult(ctx context.Context, result models.NotificationResult) error {
+	p.calls++
+	if p.failures < 0 || p.calls <= p.failures {
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, result)
+	return nil
+}
+
+// newTestDispatcher delivers through ch. Redis is unreachable, so preferences
+// come from storage.
+func newTestDispatcher(t *testing.T, ch channel.Channel, results ResultPublisher) *Dispatcher {
+	t.Helper()
+	redisClient := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1, DialTimeout: 100 * time.Millisecond})
+	t.Cleanup(func() { redisClient.Close() })
+
+	registry := channel.NewRegistry()
+	registry.Register(ch)
+	d := NewDispatcher(NewNotificationService(defaultsStorage{}, redisClient), defaultsStorage{}, registry, results)
+	d.retryBase = time.Millisecond
+	d.retryMax = 5 * time.Millisecond
+	return d
+}
+
+// A receipt that fails to publish is retried without notifying the user again.
+func TestDispatchRetriesResultWithoutResending(t *testing.T) {
+	ch := &countingChannel{}
+	publisher := &flakyPublisher{failures: 2}
+	d := newTestDispatcher(t, ch, publisher)
+
+	reminder := models.Reminder{ID: uuid.New(), UserID: uuid.New(), Title: "t"}
+	if err := d.Dispatch(context.Background(), reminder); err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+
+	if ch.sent != 1 {
+		t.Errorf("channel sent %d messages, want 1", ch.sent)
+	}
+	if publisher.calls != 3 || len(publisher.published) != 1 {
+		t.Fatalf("published %d results in %d calls, want 1 in 3", len(publisher.published), publisher.calls)
+	}
+	if got := publisher.published[0]; got.ReminderID != reminder.ID || got.Status != models.DeliveryStatusDelivered {
+		t.Errorf("published %+v, want a delivered result for %s", got, reminder.ID)
+	}
+}
+
+// If the receipt still cannot be published when ctx ends, Dispatch says so
+// and the caller keeps the trigger.
+func TestDispatchReportsUnpublishedResult(t *testing.T) {
+	ch := &countingChannel{}
+	d := newTestDispatcher(t, ch, &flakyPublisher{failures: -1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := d.Dispatch(ctx, models.Reminder{ID: uuid.New(), UserID: uuid.New()}); err == nil {
+		t.Fatal("Dispatch = nil, want an error for the unpublished result")
+	}
+	if ch.sent != 1 {
+		t.Errorf("channel sent %d messages, want 1", ch.sent)
+	}
+}
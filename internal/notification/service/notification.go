@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/internal/notification/channel"
+	"github.com/kiribu/jwt-practice/internal/notification/storage"
+	"github.com/kiribu/jwt-practice/models"
+	"github.com/redis/go-redis/v9"
+)
+
+const maxWebhooksPerUser = 10
+
+type NotificationService struct {
+	storage storage.NotificationStorage
+	redis   *redis.Client
+}
+
+func NewNotificationService(storage storage.NotificationStorage, redisClient *redis.Client) *NotificationService {
+	return &NotificationService{
+		storage: storage,
+		redis:   redisClient,
+	}
+}
+
+func (s *NotificationService) CreateWebhook(ctx context.Context, userID uuid.UUID, rawURL string) (*models.Webhook, error) {
+	if err := validateWebhookURL(ctx, rawURL); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.storage.GetWebhooksByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxWebhooksPerUser {
+		return nil, errors.New("webhook limit reached")
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.storage.CreateWebhook(ctx, userID, rawURL, secret)
+}
+
+func (s *NotificationService) ListWebhooks(ctx context.Context, userID uuid.UUID) ([]models.Webhook, error) {
+	return s.storage.GetWebhooksByUserID(ctx, userID)
+}
+
+func (s *NotificationService) GetWebhook(ctx context.Context, userID, id uuid.UUID) (*models.Webhook, error) {
+	return s.storage.GetWebhook(ctx, userID, id)
+}
+
+func (s *NotificationService) UpdateWebhook(ctx context.Context, userID, id uuid.UUID, rawURL string, enabled bool) (*models.Webhook, error) {
+	if err := validateWebhookURL(ctx, rawURL); err != nil {
+		return nil, err
+	}
+	return s.storage.UpdateWebhook(ctx, userID, id, rawURL, enabled)
+}
+
+func (s *NotificationService) RotateWebhookSecret(ctx context.Context, userID, id uuid.UUID) (*models.Webhook, error) {
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, err
+	}
+	return s.storage.UpdateWebhookSecret(ctx, userID, id, secret)
+}
+
+func (s *NotificationService) DeleteWebhook(ctx context.Context, userID, id uuid.UUID) error {
+	return s.storage.DeleteWebhook(ctx, userID, id)
+}
+
+func validateWebhookURL(ctx context.Context, rawURL string) error {
+	if rawURL == "" {
+		return errors.New("url is required")
+	}
+	return channel.CheckWebhookURL(ctx, rawURL)
+}
+
+// generateSecret returns a random 32-byte key, hex encoded.
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/internal/notification/storage"
+	"github.com/kiribu/jwt-practice/models"
+)
+
+const (
+	defaultTimezone     = "UTC"
+	preferencesCacheTTL = time.Hour
+)
+
+// supportedChannels lists the channel names a user may enable. Whether a
+// channel is actually available depends on NOTIFICATION_CHANNELS.
+var supportedChannels = map[string]bool{
+	"log":     true,
+	"email":   true,
+	"webhook": true,
+	"push":    true,
+}
+
+func preferencesCacheKey(userID uuid.UUID) string {
+	return "notification_prefs:" + userID.String()
+}
+
+// GetPreferences returns the user's preferences, falling back to the defaults
+// for users that never saved any.
+func (s *NotificationService) GetPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error) {
+	// Check Cache
+	cacheKey := preferencesCacheKey(userID)
+	val, err := s.redis.Get(ctx, cacheKey).Result()
+	if err == nil {
+		// Cache Hit
+		slog.Debug("Cache hit for notification preferences", "user_id", userID)
+		var prefs models.NotificationPreferences
+		if err := json.Unmarshal([]byte(val), &prefs); err == nil {
+			return &prefs, nil
+		}
+	}
+
+	// Cache Miss
+	slog.Debug("Cache miss for notification preferences", "user_id", userID)
+	prefs, err := s.storage.GetPreferences(ctx, userID)
+	if errors.Is(err, storage.ErrPreferencesNotFound) {
+		prefs = &models.NotificationPreferences{
+			UserID:   userID,
+			Channels: models.ChannelPreferences{},
+			Timezone: defaultTimezone,
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Set Cache
+	s.cachePreferences(ctx, prefs)
+
+	return prefs, nil
+}
+
+func (s *NotificationService) UpdatePreferences(ctx context.Context, userID uuid.UUID, channels []models.ChannelPreference, quietStart, quietEnd, timezone string) (*models.NotificationPreferences, error) {
+	if timezone == "" {
+		timezone = defaultTimezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, fmt.Errorf("invalid timezone: %s", timezone)
+	}
+	if err := validateQuietHours(quietStart, quietEnd); err != nil {
+		return nil, err
+	}
+	if err := validateChannels(channels); err != nil {
+		return nil, err
+	}
+
+	prefs := &models.NotificationPreferences{
+		UserID:          userID,
+		Channels:        models.ChannelPreferences(channels),
+		QuietHoursStart: quietStart,
+		QuietHoursEnd:   quietEnd,
+		Timezone:        timezone,
+		UpdatedAt:       time.Now(),
+	}
+	if prefs.Channels == nil {
+		prefs.Channels = models.ChannelPreferences{}
+	}
+
+	if err := s.storage.SavePreferences(ctx, prefs); err != nil {
+		return nil, err
+	}
+
+	s.cachePreferences(ctx, prefs)
+
+	return prefs, nil
+}
+
+func (s *NotificationService) cachePreferences(ctx context.Context, prefs *models.NotificationPreferences) {
+	if prefsJSON, err := json.Marshal(prefs); err == nil {
+		s.redis.Set(ctx, preferencesCacheKey(prefs.UserID), prefsJSON, preferencesCacheTTL)
+	}
+}
+
+func validateQuietHours(start, end string) error {
+	if start == "" && end == "" {
+		return nil
+	}
+	if start == "" || end == "" {
+		return errors.New("quiet_hours_start and quiet_hours_end must be set together")
+	}
+	if _, err := parseClock(start); err != nil {
+		return fmt.Errorf("invalid quiet_hours_start: %s", start)
+	}
+	if _, err := parseClock(end); err != nil {
+		return fmt.Errorf("invalid quiet_hours_end: %s", end)
+	}
+	if start == end {
+		return errors.New("quiet hours window must not be empty")
+	}
+	return nil
+}
+
+func validateChannels(channels []models.ChannelPreference) error {
+	seen := make(map[string]bool, len(channels))
+	for _, ch := range channels {
+		if !supportedChannels[ch.Channel] {
+			return fmt.Errorf("unknown channel: %s", ch.Channel)
+		}
+		if seen[ch.Channel] {
+			return fmt.Errorf("duplicate channel: %s", ch.Channel)
+		}
+		seen[ch.Channel] = true
+
+		if ch.Channel == "email" && ch.Address != "" {
+			if _, err := mail.ParseAddress(ch.Address); err != nil {
+				return fmt.Errorf("invalid email address: %s", ch.Address)
+			}
+		}
+	}
+	return nil
+}
+
+// parseClock returns minutes since midnight for an "HH:MM" string.
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// QuietHoursEnd reports whether now falls inside the user's quiet hours and,
+// if so, when they end. Windows that cross midnight (22:00-08:00) are supported.
+func QuietHoursEnd(prefs *models.NotificationPreferences, now time.Time) (time.Time, bool) {
+	if prefs == nil || prefs.QuietHoursStart == "" || prefs.QuietHoursEnd == "" {
+		return time.Time{}, false
+	}
+	start, err := parseClock(prefs.QuietHoursStart)
+	if err != nil {
+		return time.Time{}, false
+	}
+	end, err := parseClock(prefs.QuietHoursEnd)
+	if err != nil || start == end {
+		return time.Time{}, false
+	}
+
+	loc, err := time.LoadLocation(prefs.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := now.In(loc)
+	current := local.Hour()*60 + local.Minute()
+
+	var quiet bool
+	if start < end {
+		quiet = current >= start && current < end
+	} else {
+		quiet = current >= start || current < end
+	}
+	if !quiet {
+		return time.Time{}, false
+	}
+
+	until := time.Date(local.Year(), local.Month(), local.Day(), end/60, end%60, 0, 0, loc)
+	if !until.After(local) {
+		until = until.AddDate(0, 0, 1)
+	}
+	return until, true
+}
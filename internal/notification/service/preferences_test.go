@@ -0,0 +1,67 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kiribu/jwt-practice/models"
+)
+
+func TestQuietHoursEnd(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    string
+		end      string
+		timezone string
+		now      string
+		want     string // Empty when now is outside quiet hours
+	}{
+		{"no quiet hours", "", "", "UTC", "2026-03-02T14:00:00Z", ""},
+		{"same day inside", "13:00", "15:00", "UTC", "2026-03-02T14:00:00Z", "2026-03-02T15:00:00Z"},
+		{"same day before", "13:00", "15:00", "UTC", "2026-03-02T12:59:00Z", ""},
+		{"same day after", "13:00", "15:00", "UTC", "2026-03-02T16:00:00Z", ""},
+		{"at the start", "13:00", "15:00", "UTC", "2026-03-02T13:00:00Z", "2026-03-02T15:00:00Z"},
+		{"at the end", "13:00", "15:00", "UTC", "2026-03-02T15:00:00Z", ""},
+
+		{"cross-midnight before midnight", "22:00", "07:00", "UTC", "2026-03-02T23:30:00Z", "2026-03-03T07:00:00Z"},
+		{"cross-midnight after midnight", "22:00", "07:00", "UTC", "2026-03-03T03:00:00Z", "2026-03-03T07:00:00Z"},
+		{"cross-midnight at the start", "22:00", "07:00", "UTC", "2026-03-02T22:00:00Z", "2026-03-03T07:00:00Z"},
+		{"cross-midnight at the end", "22:00", "07:00", "UTC", "2026-03-03T07:00:00Z", ""},
+		{"cross-midnight during the day", "22:00", "07:00", "UTC", "2026-03-02T12:00:00Z", ""},
+
+		// 22:30 in Berlin, 16:30 in New York, 23:00 in Tokyo
+		{"ahead of UTC", "22:00", "07:00", "Europe/Berlin", "2026-03-02T21:30:00Z", "2026-03-03T06:00:00Z"},
+		{"behind UTC", "22:00", "07:00", "America/New_York", "2026-03-02T21:30:00Z", ""},
+		{"ends on the UTC day before", "22:00", "07:00", "Asia/Tokyo", "2026-03-02T14:00:00Z", "2026-03-02T22:00:00Z"},
+		{"clocks go forward overnight", "22:00", "07:00", "Europe/Berlin", "2026-03-28T22:00:00Z", "2026-03-29T05:00:00Z"},
+		{"unknown timezone falls back to UTC", "22:00", "07:00", "Mars/Olympus_Mons", "2026-03-02T23:00:00Z", "2026-03-03T07:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now, err := time.Parse(time.RFC3339, tt.now)
+			if err != nil {
+				t.Fatalf("parse now: %v", err)
+			}
+			prefs := &models.NotificationPreferences{QuietHoursStart: tt.start, QuietHoursEnd: tt.end, Timezone: tt.timezone}
+
+			until, quiet := QuietHoursEnd(prefs, now)
+			if tt.want == "" {
+				if quiet {
+					t.Errorf("QuietHoursEnd = %s, want outside quiet hours", until)
+				}
+				return
+			}
+			want, err := time.Parse(time.RFC3339, tt.want)
+			if err != nil {
+				t.Fatalf("parse want: %v", err)
+			}
+			if !quiet {
+				t.Fatalf("QuietHoursEnd reported no quiet hours, want until %s", want)
+			}
+			if !until.Equal(want) {
+				t.Errorf("QuietHoursEnd = %s, want %s", until.UTC(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrPreferencesNotFound = errors.New("notification preferences not found")
+
+type NotificationStorage interface {
+	CreateWebhook(ctx context.Context, userID uuid.UUID, url, secret string) (*models.Webhook, error)
+	GetWebhooksByUserID(ctx context.Context, userID uuid.UUID) ([]models.Webhook, error)
+	GetEnabledWebhooks(ctx context.Context, userID uuid.UUID) ([]models.Webhook, error)
+	GetWebhook(ctx context.Context, userID, id uuid.UUID) (*models.Webhook, error)
+	UpdateWebhook(ctx context.Context, userID, id uuid.UUID, url string, enabled bool) (*models.Webhook, error)
+	UpdateWebhookSecret(ctx context.Context, userID, id uuid.UUID, secret string) (*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, userID, id uuid.UUID) error
+
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error)
+	SavePreferences(ctx context.Context, prefs *models.NotificationPreferences) error
+
+	CreateDeferredDelivery(ctx context.Context, delivery *models.DeferredDelivery) error
+	// ClaimDueDeferredDeliveries returns up to limit deliveries due at now and
+	// hides them from other callers for lease, so only one replica releases each.
+	ClaimDueDeferredDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]models.DeferredDelivery, error)
+	DeleteDeferredDelivery(ctx context.Context, id uuid.UUID) error
+
+	CreateWebhookRetry(ctx context.Context, retry *models.WebhookRetry) error
+	// ClaimDueWebhookRetries returns up to limit retries due at now and hides
+	// them from other callers for lease, so only one replica attempts each.
+	ClaimDueWebhookRetries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]models.WebhookRetry, error)
+	RescheduleWebhookRetry(ctx context.Context, id uuid.UUID, attempts int, nextAttemptAt time.Time, lastError string) error
+	DeleteWebhookRetry(ctx context.Context, id uuid.UUID) error
+}
+
+type PostgresStorage struct {
+	db *gorm.DB
+}
+
+func NewPostgresStorage(db *gorm.DB) *PostgresStorage {
+	return &PostgresStorage{db: db}
+}
+
+func (s *PostgresStorage) CreateWebhook(ctx context.Context, userID uuid.UUID, url, secret string) (*models.Webhook, error) {
+	webhook := &models.Webhook{
+		ID:      uuid.Must(uuid.NewV7()),
+		UserID:  userID,
+		URL:     url,
+		Secret:  secret,
+		Enabled: true,
+	}
+
+	if err := s.db.WithContext(ctx).Create(webhook).Error; err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (s *PostgresStorage) GetWebhooksByUserID(ctx context.Context, userID uuid.UUID) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := s.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at ASC").Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (s *PostgresStorage) GetEnabledWebhooks(ctx context.Context, userID uuid.UUID) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := s.db.WithContext(ctx).Where("user_id = ? AND enabled = ?", userID, true).Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (s *PostgresStorage) GetWebhook(ctx context.Context, userID, id uuid.UUID) (*models.Webhook, error) {
+	var webhook models.Webhook
+	result := s.db.WithContext(ctx).Where("user_id = ? AND id = ?", userID, id).First(&webhook)
+	if result.Error != nil {
+		return nil, errors.New("webhook not found")
+	}
+	return &webhook, nil
+}
+
+func (s *PostgresStorage) UpdateWebhook(ctx context.Context, userID, id uuid.UUID, url string, enabled bool) (*models.Webhook, error) {
+	webhook, err := s.GetWebhook(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.URL = url
+	webhook.Enabled = enabled
+
+	if err := s.db.WithContext(ctx).Save(webhook).Error; err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (s *PostgresStorage) UpdateWebhookSecret(ctx context.Context, userID, id uuid.UUID, secret string) (*models.Webhook, error) {
+	webhook, err := s.GetWebhook(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.Secret = secret
+
+	if err := s.db.WithContext(ctx).Save(webhook).Error; err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (s *PostgresStorage) DeleteWebhook(ctx context.Context, userID, id uuid.UUID) error {
+	result := s.db.WithContext(ctx).Where("user_id = ? AND id = ?", userID, id).Delete(&models.Webhook{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("webhook not found")
+	}
+	return nil
+}
+
+func (s *PostgresStorage) GetPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error) {
+	var prefs models.NotificationPreferences
+	result := s.db.WithContext(ctx).Where("user_id = ?", userID).First(&prefs)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, ErrPreferencesNotFound
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &prefs, nil
+}
+
+// SavePreferences inserts or replaces the preferences of prefs.UserID.
+func (s *PostgresStorage) SavePreferences(ctx context.Context, prefs *models.NotificationPreferences) error {
+	return s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"channels", "quiet_hours_start", "quiet_hours_end", "timezone", "updated_at"}),
+	}).Create(prefs).Error
+}
+
+func (s *PostgresStorage) CreateDeferredDelivery(ctx context.Context, delivery *models.DeferredDelivery) error {
+	if delivery.ID == uuid.Nil {
+		delivery.ID = uuid.Must(uuid.NewV7())
+	}
+	return s.db.WithContext(ctx).Create(delivery).Error
+}
+
+// ClaimDueDeferredDeliveries locks due rows with FOR UPDATE SKIP LOCKED and
+// moves their deliver_at past the lease in the same transaction, like
+// ClaimDueWebhookRetries. The caller deletes each row once it is dispatched.
+func (s *PostgresStorage) ClaimDueDeferredDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]models.DeferredDelivery, error) {
+	var deliveries []models.DeferredDelivery
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("deliver_at <= ?", now).
+			Order("deliver_at ASC").
+			Limit(limit).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, len(deliveries))
+		for i, delivery := range deliveries {
+			ids[i] = delivery.ID
+		}
+		return tx.Model(&models.DeferredDelivery{}).
+			Where("id IN ?", ids).
+			Update("deliver_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (s *PostgresStorage) DeleteDeferredDelivery(ctx context.Context, id uuid.UUID) error {
+	return s.db.WithContext(ctx).Where("id = ?", id).Delete(&models.DeferredDelivery{}).Error
+}
+
+func (s *PostgresStorage) CreateWebhookRetry(ctx context.Context, retry *models.WebhookRetry) error {
+	if retry.ID == uuid.Nil {
+		retry.ID = uuid.Must(uuid.NewV7())
+	}
+	return s.db.WithContext(ctx).Create(retry).Error
+}
+
+// ClaimDueWebhookRetries locks due rows with FOR UPDATE SKIP LOCKED and moves
+// their next_attempt_at past the lease in the same transaction. A replica that
+// crashes mid-attempt leaves the row to be claimed again once the lease ends.
+func (s *PostgresStorage) ClaimDueWebhookRetries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]models.WebhookRetry, error) {
+	var retries []models.WebhookRetry
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("next_attempt_at <= ?", now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&retries).Error
+		if err != nil || len(retries) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, len(retries))
+		for i, retry := range retries {
+			ids[i] = retry.ID
+		}
+		return tx.Model(&models.WebhookRetry{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return retries, nil
+}
+
+func (s *PostgresStorage) RescheduleWebhookRetry(ctx context.Context, id uuid.UUID, attempts int, nextAttemptAt time.Time, lastError string) error {
+	return s.db.WithContext(ctx).Model(&models.WebhookRetry{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"attempts":        attempts,
+			"next_attempt_at": nextAttemptAt,
+			"last_error":      lastError,
+		}).Error
+}
+
+func (s *PostgresStorage) DeleteWebhookRetry(ctx context.Context, id uuid.UUID) error {
+	return s.db.WithContext(ctx).Where("id = ?", id).Delete(&models.WebhookRetry{}).Error
+}
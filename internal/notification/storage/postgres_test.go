@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/internal/testdb"
+	"github.com/kiribu/jwt-practice/models"
+)
+
+// Every notification-service replica runs the deferred worker; each due
+// delivery must be handed to exactly one of them.
+func TestClaimDueDeferredDeliveriesConcurrently(t *testing.T) {
+	store := NewPostgresStorage(testdb.Open(t))
+	ctx := context.Background()
+
+	const deliveries, replicas = 200, 8
+	past := time.Now().Add(-time.Minute)
+	for range deliveries {
+		err := store.CreateDeferredDelivery(ctx, &models.DeferredDelivery{
+			ReminderID: uuid.New(),
+			UserID:     uuid.New(),
+			Payload:    []byte(`{}`),
+			DeliverAt:  past,
+		})
+		if err != nil {
+			t.Fatalf("CreateDeferredDelivery: %v", err)
+		}
+	}
+
+	var mu sync.Mutex
+	claims := make(map[uuid.UUID]int)
+	var wg sync.WaitGroup
+	for range replicas {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				batch, err := store.ClaimDueDeferredDeliveries(ctx, time.Now(), 7, time.Hour)
+				if err != nil {
+					t.Errorf("ClaimDueDeferredDeliveries: %v", err)
+					return
+				}
+				if len(batch) == 0 {
+					return
+				}
+				mu.Lock()
+				for _, d := range batch {
+					claims[d.ID]++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(claims) != deliveries {
+		t.Errorf("claimed %d distinct deliveries, want %d", len(claims), deliveries)
+	}
+	for id, n := range claims {
+		if n != 1 {
+			t.Errorf("delivery %s claimed %d times", id, n)
+		}
+	}
+
+	// Claimed rows stay hidden until the lease ends
+	again, err := store.ClaimDueDeferredDeliveries(ctx, time.Now(), deliveries, time.Hour)
+	if err != nil {
+		t.Fatalf("ClaimDueDeferredDeliveries: %v", err)
+	}
+	if len(again) != 0 {
+		t.Errorf("claimed %d deliveries again within the lease, want 0", len(again))
+	}
+	expired, err := store.ClaimDueDeferredDeliveries(ctx, time.Now().Add(2*time.Hour), deliveries, time.Hour)
+	if err != nil {
+		t.Fatalf("ClaimDueDeferredDeliveries: %v", err)
+	}
+	if len(expired) != deliveries {
+		t.Errorf("claimed %d deliveries after the lease, want %d", len(expired), deliveries)
+	}
+}
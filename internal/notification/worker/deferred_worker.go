@@ -0,0 +1,79 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/kiribu/jwt-practice/internal/notification/service"
+	"github.com/kiribu/jwt-practice/internal/notification/storage"
+	"github.com/kiribu/jwt-practice/models"
+)
+
+const (
+	deferredBatchSize = 100
+	// deferredLease hides claimed deliveries from other replicas while they are
+	// dispatched; a replica that dies mid-batch releases them when it ends.
+	deferredLease = 5 * time.Minute
+)
+
+// DeferredWorker releases reminders held back by quiet hours once the window is over.
+type DeferredWorker struct {
+	storage    storage.NotificationStorage
+	dispatcher *service.Dispatcher
+	interval   time.Duration
+}
+
+func NewDeferredWorker(storage storage.NotificationStorage, dispatcher *service.Dispatcher, interval time.Duration) *DeferredWorker {
+	return &DeferredWorker{
+		storage:    storage,
+		dispatcher: dispatcher,
+		interval:   interval,
+	}
+}
+
+func (w *DeferredWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	slog.Info("Deferred delivery worker started", "interval", w.interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Stopping deferred delivery worker...")
+			return
+		case <-ticker.C:
+			w.processDue(ctx)
+		}
+	}
+}
+
+func (w *DeferredWorker) processDue(ctx context.Context) {
+	deliveries, err := w.storage.ClaimDueDeferredDeliveries(ctx, time.Now(), deferredBatchSize, deferredLease)
+	if err != nil {
+		slog.Error("Error fetching deferred deliveries", "error", err)
+		return
+	}
+
+	if len(deliveries) > 0 {
+		slog.Info("Releasing deferred deliveries", "count", len(deliveries))
+	}
+
+	for _, delivery := range deliveries {
+		// Preferences may have changed meanwhile, so quiet hours are checked again.
+		var reminder models.Reminder
+		if err := json.Unmarshal(delivery.Payload, &reminder); err != nil {
+			slog.Error("Failed to unmarshal deferred reminder", "id", delivery.ID, "error", err)
+		} else if err := w.dispatcher.Dispatch(ctx, reminder); err != nil {
+			// Kept and released again once the lease ends
+			slog.Error("Failed to dispatch deferred reminder", "id", delivery.ID, "error", err)
+			continue
+		}
+
+		if err := w.storage.DeleteDeferredDelivery(ctx, delivery.ID); err != nil {
+			slog.Error("Failed to delete deferred delivery", "id", delivery.ID, "error", err)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/internal/notification/channel"
+	"github.com/kiribu/jwt-practice/internal/notification/service"
+	"github.com/kiribu/jwt-practice/internal/notification/storage"
+	"github.com/kiribu/jwt-practice/models"
+)
+
+const (
+	webhookRetryBatchSize = 100
+	// webhookRetryLease hides a claimed retry from other replicas while it is
+	// attempted; it must outlast one request timeout.
+	webhookRetryLease = 2 * time.Minute
+)
+
+// WebhookRetryWorker retries failed webhook deliveries queued by the webhook
+// channel and reports their final outcome as a delivery result.
+type WebhookRetryWorker struct {
+	storage  storage.NotificationStorage
+	webhooks *channel.WebhookChannel
+	results  service.ResultPublisher
+	interval time.Duration
+}
+
+func NewWebhookRetryWorker(storage storage.NotificationStorage, webhooks *channel.WebhookChannel, results service.ResultPublisher, interval time.Duration) *WebhookRetryWorker {
+	return &WebhookRetryWorker{
+		storage:  storage,
+		webhooks: webhooks,
+		results:  results,
+		interval: interval,
+	}
+}
+
+func (w *WebhookRetryWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	slog.Info("Webhook retry worker started", "interval", w.interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Stopping webhook retry worker...")
+			return
+		case <-ticker.C:
+			w.processDue(ctx)
+		}
+	}
+}
+
+func (w *WebhookRetryWorker) processDue(ctx context.Context) {
+	retries, err := w.storage.ClaimDueWebhookRetries(ctx, time.Now(), webhookRetryBatchSize, webhookRetryLease)
+	if err != nil {
+		slog.Error("Error claiming webhook retries", "error", err)
+		return
+	}
+
+	for _, retry := range retries {
+		w.attempt(ctx, retry)
+	}
+}
+
+// attempt makes one retry. Success and the last failed attempt end the retry
+// and publish a result; a "failed" result for the first attempt was already
+// sent, and reminder-service accepts a later "delivered".
+func (w *WebhookRetryWorker) attempt(ctx context.Context, retry models.WebhookRetry) {
+	err := w.webhooks.Redeliver(ctx, retry)
+	attempts := retry.Attempts + 1
+
+	switch {
+	case err == nil:
+		slog.Info("Webhook delivered on retry", "webhook_id", retry.WebhookID, "reminder_id", retry.ReminderID, "attempt", attempts)
+		w.finish(ctx, retry, models.DeliveryStatusDelivered, "")
+	case errors.Is(err, channel.ErrWebhookGone):
+		slog.Info("Dropping webhook retry", "webhook_id", retry.WebhookID, "reminder_id", retry.ReminderID, "reason", err)
+		w.delete(ctx, retry)
+	case attempts >= w.webhooks.MaxAttempts():
+		slog.Warn("Giving up on webhook", "webhook_id", retry.WebhookID, "reminder_id", retry.ReminderID, "attempts", attempts, "error", err)
+		w.finish(ctx, retry, models.DeliveryStatusFailed, fmt.Sprintf("giving up after %d attempts: %v", attempts, err))
+	default:
+		next := time.Now().Add(w.webhooks.Backoff(attempts))
+		slog.Warn("Webhook retry failed", "webhook_id", retry.WebhookID, "reminder_id", retry.ReminderID, "attempt", attempts, "next_attempt_at", next, "error", err)
+		if err := w.storage.RescheduleWebhookRetry(ctx, retry.ID, attempts, next, err.Error()); err != nil {
+			slog.Error("Failed to reschedule webhook retry", "id", retry.ID, "error", err)
+		}
+	}
+}
+
+func (w *WebhookRetryWorker) finish(ctx context.Context, retry models.WebhookRetry, status, reason string) {
+	result := models.NotificationResult{
+		// Derived from the retry, so a result published again after a failed delete is deduplicated
+		ResultID:   uuid.NewSHA1(retry.ID, []byte(status)),
+		ReminderID: retry.ReminderID,
+		UserID:     retry.UserID,
+		Status:     status,
+		Channel:    "webhook",
+		Reason:     reason,
+		Timestamp:  time.Now(),
+	}
+	if err := w.results.SendResult(ctx, result); err != nil {
+		// Keep the row; the lease expires and the retry runs again
+		slog.Error("Failed to publish webhook retry result", "reminder_id", retry.ReminderID, "error", err)
+		return
+	}
+	w.delete(ctx, retry)
+}
+
+func (w *WebhookRetryWorker) delete(ctx context.Context, retry models.WebhookRetry) {
+	if err := w.storage.DeleteWebhookRetry(ctx, retry.ID); err != nil {
+		slog.Error("Failed to delete webhook retry", "id", retry.ID, "error", err)
+	}
+}
@@ -0,0 +1,140 @@
+package worker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/internal/notification/channel"
+	"github.com/kiribu/jwt-practice/internal/notification/storage"
+	"github.com/kiribu/jwt-practice/models"
+)
+
+// retryStorage serves one user's webhooks and keeps retries in memory.
+type retryStorage struct {
+	storage.NotificationStorage
+	webhooks []models.Webhook
+	retries  map[uuid.UUID]models.WebhookRetry
+}
+
+func (s *retryStorage) GetEnabledWebhooks(ctx context.Context, userID uuid.UUID) ([]models.Webhook, error) {
+	return s.webhooks, nil
+}
+
+func (s *retryStorage) CreateWebhookRetry(ctx context.Context, retry *models.WebhookRetry) error {
+	retry.ID = uuid.New()
+	s.retries[retry.ID] = *retry
+	return nil
+}
+
+func (s *retryStorage) ClaimDueWebhookRetries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]models.WebhookRetry, error) {
+	var due []models.WebhookRetry
+	for id, retry := range s.retries {
+		if !retry.NextAttemptAt.After(now) {
+			due = append(due, retry)
+			retry.NextAttemptAt = now.Add(lease)
+			s.retries[id] = retry
+		}
+	}
+	return due, nil
+}
+
+func (s *retryStorage) RescheduleWebhookRetry(ctx context.Context, id uuid.UUID, attempts int, nextAttemptAt time.Time, lastError string) error {
+	retry := s.retries[id]
+	retry.Attempts = attempts
+	retry.NextAttemptAt = nextAttemptAt
+	retry.LastError = lastError
+	s.retries[id] = retry
+	return nil
+}
+
+func (s *retryStorage) DeleteWebhookRetry(ctx context.Context, id uuid.UUID) error {
+	delete(s.retries, id)
+	return nil
+}
+
+type resultRecorder struct {
+	results []models.NotificationResult
+}
+
+func (r *resultRecorder) SendResult(ctx context.Context, result models.NotificationResult) error {
+	r.results = append(r.results, result)
+	return nil
+}
+
+// newRetryFixture returns a worker whose only endpoint answers with the given
+// statuses in turn, and a retry queued for it by a failed Send.
+func newRetryFixture(t *testing.T, maxAttempts int, statuses ...int) (*WebhookRetryWorker, *retryStorage, *resultRecorder) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statuses[0])
+		if len(statuses) > 1 {
+			statuses = statuses[1:]
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	store := &retryStorage{
+		webhooks: []models.Webhook{{ID: uuid.New(), URL: server.URL, Secret: "s", Enabled: true}},
+		retries:  make(map[uuid.UUID]models.WebhookRetry),
+	}
+	webhooks := channel.NewWebhookChannel(store, store, channel.WebhookConfig{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: time.Nanosecond,
+		MaxBackoff:     time.Nanosecond,
+		Client:         server.Client(),
+	})
+	results := &resultRecorder{}
+
+	msg := channel.Message{ReminderID: uuid.New(), UserID: uuid.New(), Title: "t", RemindAt: time.Now()}
+	if err := webhooks.Send(context.Background(), msg); err == nil {
+		t.Fatal("first attempt succeeded, want it to fail and queue a retry")
+	}
+	time.Sleep(time.Millisecond)
+
+	return NewWebhookRetryWorker(store, webhooks, results, time.Second), store, results
+}
+
+func TestWebhookRetryWorkerDeliversOnRetry(t *testing.T) {
+	w, store, results := newRetryFixture(t, 5, http.StatusBadGateway, http.StatusOK)
+
+	w.processDue(context.Background())
+
+	if len(store.retries) != 0 {
+		t.Errorf("%d retries left, want 0", len(store.retries))
+	}
+	if len(results.results) != 1 || results.results[0].Status != models.DeliveryStatusDelivered {
+		t.Fatalf("results = %+v, want one delivered", results.results)
+	}
+}
+
+func TestWebhookRetryWorkerGivesUp(t *testing.T) {
+	w, store, results := newRetryFixture(t, 3, http.StatusInternalServerError)
+
+	for range 3 {
+		w.processDue(context.Background())
+		time.Sleep(time.Millisecond)
+	}
+
+	if len(store.retries) != 0 {
+		t.Errorf("%d retries left, want 0", len(store.retries))
+	}
+	if len(results.results) != 1 || results.results[0].Status != models.DeliveryStatusFailed {
+		t.Fatalf("results = %+v, want one failed", results.results)
+	}
+}
+
+func TestWebhookRetryWorkerDropsDisabledEndpoint(t *testing.T) {
+	w, store, results := newRetryFixture(t, 5, http.StatusInternalServerError)
+	store.webhooks = nil
+
+	w.processDue(context.Background())
+
+	if len(store.retries) != 0 || len(results.results) != 0 {
+		t.Errorf("retries = %d, results = %d, want the retry dropped silently", len(store.retries), len(results.results))
+	}
+}
@@ -0,0 +1,134 @@
+package remindergrpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/internal/reminder/grpc/pb"
+	"github.com/kiribu/jwt-practice/internal/reminder/service"
+	"github.com/kiribu/jwt-practice/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type AdminServer struct {
+	pb.UnimplementedReminderAdminServiceServer
+	service *service.OutboxAdminService
+}
+
+func NewAdminServer(svc *service.OutboxAdminService) *AdminServer {
+	return &AdminServer{service: svc}
+}
+
+func (s *AdminServer) ListFailedEvents(ctx context.Context, req *pb.ListFailedEventsRequest) (*pb.ListFailedEventsResponse, error) {
+	events, err := s.service.ListFailed(ctx, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	var protoEvents []*pb.OutboxEventResponse
+	for _, e := range events {
+		protoEvents = append(protoEvents, toProtoOutboxEvent(&e))
+	}
+
+	return &pb.ListFailedEventsResponse{Events: protoEvents}, nil
+}
+
+func (s *AdminServer) GetEvent(ctx context.Context, req *pb.GetEventRequest) (*pb.OutboxEventResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid id: %v", err)
+	}
+
+	event, err := s.service.GetEvent(ctx, id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return toProtoOutboxEvent(event), nil
+}
+
+func (s *AdminServer) RequeueEvents(ctx context.Context, req *pb.ResolveEventsRequest) (*pb.ResolveEventsResponse, error) {
+	ids, err := parseIDs(req.Ids)
+	if err != nil {
+		return nil, err
+	}
+
+	requeued, err := s.service.Requeue(ctx, ids, req.Actor)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &pb.ResolveEventsResponse{Ids: toStrings(requeued)}, nil
+}
+
+func (s *AdminServer) DiscardEvents(ctx context.Context, req *pb.ResolveEventsRequest) (*pb.ResolveEventsResponse, error) {
+	ids, err := parseIDs(req.Ids)
+	if err != nil {
+		return nil, err
+	}
+
+	discarded, err := s.service.Discard(ctx, ids, req.Actor)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &pb.ResolveEventsResponse{Ids: toStrings(discarded)}, nil
+}
+
+func (s *AdminServer) ListAuditLog(ctx context.Context, req *pb.ListAuditLogRequest) (*pb.ListAuditLogResponse, error) {
+	entries, err := s.service.AuditLog(ctx, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	var protoEntries []*pb.AuditEntry
+	for _, e := range entries {
+		protoEntries = append(protoEntries, &pb.AuditEntry{
+			Id:        e.ID.String(),
+			Action:    e.Action,
+			EventId:   e.EventID.String(),
+			Actor:     e.Actor,
+			CreatedAt: e.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+
+	return &pb.ListAuditLogResponse{Entries: protoEntries}, nil
+}
+
+func parseIDs(raw []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(raw))
+	for _, s := range raw {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid id %q: %v", s, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func toStrings(ids []uuid.UUID) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, id.String())
+	}
+	return out
+}
+
+func toProtoOutboxEvent(e *models.OutboxEvent) *pb.OutboxEventResponse {
+	resp := &pb.OutboxEventResponse{
+		Id:         e.ID.String(),
+		EventType:  e.EventType,
+		ReminderId: e.AggregateID.String(),
+		UserId:     e.UserID.String(),
+		Payload:    string(e.Payload),
+		Status:     e.Status,
+		RetryCount: int32(e.RetryCount),
+		CreatedAt:  e.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if e.ErrorMessage != nil {
+		resp.ErrorMessage = *e.ErrorMessage
+	}
+	return resp
+}
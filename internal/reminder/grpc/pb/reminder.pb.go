@@ -27,6 +27,8 @@ type CreateReminderRequest struct {
 	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
 	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
 	RemindAt      string                 `protobuf:"bytes,4,opt,name=remind_at,json=remindAt,proto3" json:"remind_at,omitempty"`
+	Rrule         string                 `protobuf:"bytes,5,opt,name=rrule,proto3" json:"rrule,omitempty"`       // RFC 5545 RRULE, e.g. "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR"; empty for one-shot
+	Timezone      string                 `protobuf:"bytes,6,opt,name=timezone,proto3" json:"timezone,omitempty"` // IANA timezone the rrule is evaluated in, defaults to UTC
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -89,10 +91,24 @@ func (x *CreateReminderRequest) GetRemindAt() string {
 	return ""
 }
 
+func (x *CreateReminderRequest) GetRrule() string {
+	if x != nil {
+		return x.Rrule
+	}
+	return ""
+}
+
+func (x *CreateReminderRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
 type GetRemindersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // UUID as string
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`               // "pending", "sent", or empty for all
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`               // any status or comma-separated list of them; legacy "pending"/"sent"; empty for all but cancelled
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -200,6 +216,8 @@ type UpdateReminderRequest struct {
 	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
 	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
 	RemindAt      string                 `protobuf:"bytes,5,opt,name=remind_at,json=remindAt,proto3" json:"remind_at,omitempty"`
+	Rrule         string                 `protobuf:"bytes,6,opt,name=rrule,proto3" json:"rrule,omitempty"`
+	Timezone      string                 `protobuf:"bytes,7,opt,name=timezone,proto3" json:"timezone,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -269,6 +287,20 @@ func (x *UpdateReminderRequest) GetRemindAt() string {
 	return ""
 }
 
+func (x *UpdateReminderRequest) GetRrule() string {
+	if x != nil {
+		return x.Rrule
+	}
+	return ""
+}
+
+func (x *UpdateReminderRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
 type DeleteReminderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // UUID as string
@@ -321,23 +353,147 @@ func (x *DeleteReminderRequest) GetId() string {
 	return ""
 }
 
-type ReminderResponse struct {
+type SnoozeReminderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                       // UUID as string
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // UUID as string
-	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	RemindAt      string                 `protobuf:"bytes,5,opt,name=remind_at,json=remindAt,proto3" json:"remind_at,omitempty"`
-	IsSent        bool                   `protobuf:"varint,6,opt,name=is_sent,json=isSent,proto3" json:"is_sent,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`       // UUID as string
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`                             // UUID as string
+	Duration      string                 `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`                 // Go duration, e.g. "10m"; mutually exclusive with remind_at
+	RemindAt      string                 `protobuf:"bytes,4,opt,name=remind_at,json=remindAt,proto3" json:"remind_at,omitempty"` // RFC3339 time to re-arm at
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *SnoozeReminderRequest) Reset() {
+	*x = SnoozeReminderRequest{}
+	mi := &file_proto_reminder_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnoozeReminderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnoozeReminderRequest) ProtoMessage() {}
+
+func (x *SnoozeReminderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reminder_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnoozeReminderRequest.ProtoReflect.Descriptor instead.
+func (*SnoozeReminderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reminder_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SnoozeReminderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SnoozeReminderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SnoozeReminderRequest) GetDuration() string {
+	if x != nil {
+		return x.Duration
+	}
+	return ""
+}
+
+func (x *SnoozeReminderRequest) GetRemindAt() string {
+	if x != nil {
+		return x.RemindAt
+	}
+	return ""
+}
+
+type AcknowledgeReminderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // UUID as string
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`                       // UUID as string
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcknowledgeReminderRequest) Reset() {
+	*x = AcknowledgeReminderRequest{}
+	mi := &file_proto_reminder_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcknowledgeReminderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcknowledgeReminderRequest) ProtoMessage() {}
+
+func (x *AcknowledgeReminderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reminder_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcknowledgeReminderRequest.ProtoReflect.Descriptor instead.
+func (*AcknowledgeReminderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reminder_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AcknowledgeReminderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AcknowledgeReminderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ReminderResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                       // UUID as string
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // UUID as string
+	Title          string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description    string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	RemindAt       string                 `protobuf:"bytes,5,opt,name=remind_at,json=remindAt,proto3" json:"remind_at,omitempty"`
+	IsSent         bool                   `protobuf:"varint,6,opt,name=is_sent,json=isSent,proto3" json:"is_sent,omitempty"` // deprecated: derived from status, true once the reminder has fired
+	CreatedAt      string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Rrule          string                 `protobuf:"bytes,9,opt,name=rrule,proto3" json:"rrule,omitempty"`
+	Timezone       string                 `protobuf:"bytes,10,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	AcknowledgedAt string                 `protobuf:"bytes,11,opt,name=acknowledged_at,json=acknowledgedAt,proto3" json:"acknowledged_at,omitempty"`
+	Status         string                 `protobuf:"bytes,12,opt,name=status,proto3" json:"status,omitempty"` // scheduled, queued, delivered, failed, acknowledged, cancelled
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
 func (x *ReminderResponse) Reset() {
 	*x = ReminderResponse{}
-	mi := &file_proto_reminder_proto_msgTypes[5]
+	mi := &file_proto_reminder_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -349,7 +505,7 @@ func (x *ReminderResponse) String() string {
 func (*ReminderResponse) ProtoMessage() {}
 
 func (x *ReminderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_reminder_proto_msgTypes[5]
+	mi := &file_proto_reminder_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -362,7 +518,7 @@ func (x *ReminderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReminderResponse.ProtoReflect.Descriptor instead.
 func (*ReminderResponse) Descriptor() ([]byte, []int) {
-	return file_proto_reminder_proto_rawDescGZIP(), []int{5}
+	return file_proto_reminder_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ReminderResponse) GetId() string {
@@ -421,6 +577,34 @@ func (x *ReminderResponse) GetUpdatedAt() string {
 	return ""
 }
 
+func (x *ReminderResponse) GetRrule() string {
+	if x != nil {
+		return x.Rrule
+	}
+	return ""
+}
+
+func (x *ReminderResponse) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *ReminderResponse) GetAcknowledgedAt() string {
+	if x != nil {
+		return x.AcknowledgedAt
+	}
+	return ""
+}
+
+func (x *ReminderResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
 type GetRemindersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Reminders     []*ReminderResponse    `protobuf:"bytes,1,rep,name=reminders,proto3" json:"reminders,omitempty"`
@@ -430,7 +614,7 @@ type GetRemindersResponse struct {
 
 func (x *GetRemindersResponse) Reset() {
 	*x = GetRemindersResponse{}
-	mi := &file_proto_reminder_proto_msgTypes[6]
+	mi := &file_proto_reminder_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -442,7 +626,7 @@ func (x *GetRemindersResponse) String() string {
 func (*GetRemindersResponse) ProtoMessage() {}
 
 func (x *GetRemindersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_reminder_proto_msgTypes[6]
+	mi := &file_proto_reminder_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -455,7 +639,7 @@ func (x *GetRemindersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRemindersResponse.ProtoReflect.Descriptor instead.
 func (*GetRemindersResponse) Descriptor() ([]byte, []int) {
-	return file_proto_reminder_proto_rawDescGZIP(), []int{6}
+	return file_proto_reminder_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetRemindersResponse) GetReminders() []*ReminderResponse {
@@ -475,7 +659,7 @@ type DeleteReminderResponse struct {
 
 func (x *DeleteReminderResponse) Reset() {
 	*x = DeleteReminderResponse{}
-	mi := &file_proto_reminder_proto_msgTypes[7]
+	mi := &file_proto_reminder_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -487,7 +671,7 @@ func (x *DeleteReminderResponse) String() string {
 func (*DeleteReminderResponse) ProtoMessage() {}
 
 func (x *DeleteReminderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_reminder_proto_msgTypes[7]
+	mi := &file_proto_reminder_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -500,7 +684,7 @@ func (x *DeleteReminderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteReminderResponse.ProtoReflect.Descriptor instead.
 func (*DeleteReminderResponse) Descriptor() ([]byte, []int) {
-	return file_proto_reminder_proto_rawDescGZIP(), []int{7}
+	return file_proto_reminder_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *DeleteReminderResponse) GetSuccess() bool {
@@ -517,31 +701,215 @@ func (x *DeleteReminderResponse) GetMessage() string {
 	return ""
 }
 
+type GetDeliveryHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // UUID as string
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`                       // UUID as string
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeliveryHistoryRequest) Reset() {
+	*x = GetDeliveryHistoryRequest{}
+	mi := &file_proto_reminder_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeliveryHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeliveryHistoryRequest) ProtoMessage() {}
+
+func (x *GetDeliveryHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reminder_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeliveryHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetDeliveryHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reminder_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetDeliveryHistoryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetDeliveryHistoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeliveryAttempt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`         // UUID as string
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // "delivered" or "failed"
+	Channel       string                 `protobuf:"bytes,3,opt,name=channel,proto3" json:"channel,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"` // failure reason
+	AttemptedAt   string                 `protobuf:"bytes,5,opt,name=attempted_at,json=attemptedAt,proto3" json:"attempted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliveryAttempt) Reset() {
+	*x = DeliveryAttempt{}
+	mi := &file_proto_reminder_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryAttempt) ProtoMessage() {}
+
+func (x *DeliveryAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reminder_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryAttempt.ProtoReflect.Descriptor instead.
+func (*DeliveryAttempt) Descriptor() ([]byte, []int) {
+	return file_proto_reminder_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeliveryAttempt) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetAttemptedAt() string {
+	if x != nil {
+		return x.AttemptedAt
+	}
+	return ""
+}
+
+type GetDeliveryHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deliveries    []*DeliveryAttempt     `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeliveryHistoryResponse) Reset() {
+	*x = GetDeliveryHistoryResponse{}
+	mi := &file_proto_reminder_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeliveryHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeliveryHistoryResponse) ProtoMessage() {}
+
+func (x *GetDeliveryHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reminder_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeliveryHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetDeliveryHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_reminder_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetDeliveryHistoryResponse) GetDeliveries() []*DeliveryAttempt {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
 var File_proto_reminder_proto protoreflect.FileDescriptor
 
 const file_proto_reminder_proto_rawDesc = "" +
 	"\n" +
-	"\x14proto/reminder.proto\x12\breminder\"\x85\x01\n" +
+	"\x14proto/reminder.proto\x12\breminder\"\xb7\x01\n" +
 	"\x15CreateReminderRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1b\n" +
-	"\tremind_at\x18\x04 \x01(\tR\bremindAt\"F\n" +
+	"\tremind_at\x18\x04 \x01(\tR\bremindAt\x12\x14\n" +
+	"\x05rrule\x18\x05 \x01(\tR\x05rrule\x12\x1a\n" +
+	"\btimezone\x18\x06 \x01(\tR\btimezone\"F\n" +
 	"\x13GetRemindersRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\"=\n" +
 	"\x12GetReminderRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x0e\n" +
-	"\x02id\x18\x02 \x01(\tR\x02id\"\x95\x01\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"\xc7\x01\n" +
 	"\x15UpdateReminderRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x0e\n" +
 	"\x02id\x18\x02 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x03 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1b\n" +
-	"\tremind_at\x18\x05 \x01(\tR\bremindAt\"@\n" +
+	"\tremind_at\x18\x05 \x01(\tR\bremindAt\x12\x14\n" +
+	"\x05rrule\x18\x06 \x01(\tR\x05rrule\x12\x1a\n" +
+	"\btimezone\x18\a \x01(\tR\btimezone\"@\n" +
 	"\x15DeleteReminderRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x0e\n" +
-	"\x02id\x18\x02 \x01(\tR\x02id\"\xe7\x01\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"y\n" +
+	"\x15SnoozeReminderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x1a\n" +
+	"\bduration\x18\x03 \x01(\tR\bduration\x12\x1b\n" +
+	"\tremind_at\x18\x04 \x01(\tR\bremindAt\"E\n" +
+	"\x1aAcknowledgeReminderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"\xda\x02\n" +
 	"\x10ReminderResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
@@ -552,18 +920,39 @@ const file_proto_reminder_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\b \x01(\tR\tupdatedAt\"P\n" +
+	"updated_at\x18\b \x01(\tR\tupdatedAt\x12\x14\n" +
+	"\x05rrule\x18\t \x01(\tR\x05rrule\x12\x1a\n" +
+	"\btimezone\x18\n" +
+	" \x01(\tR\btimezone\x12'\n" +
+	"\x0facknowledged_at\x18\v \x01(\tR\x0eacknowledgedAt\x12\x16\n" +
+	"\x06status\x18\f \x01(\tR\x06status\"P\n" +
 	"\x14GetRemindersResponse\x128\n" +
 	"\treminders\x18\x01 \x03(\v2\x1a.reminder.ReminderResponseR\treminders\"L\n" +
 	"\x16DeleteReminderResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2\x9c\x03\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"D\n" +
+	"\x19GetDeliveryHistoryRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"\x8e\x01\n" +
+	"\x0fDeliveryAttempt\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
+	"\achannel\x18\x03 \x01(\tR\achannel\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12!\n" +
+	"\fattempted_at\x18\x05 \x01(\tR\vattemptedAt\"W\n" +
+	"\x1aGetDeliveryHistoryResponse\x129\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x19.reminder.DeliveryAttemptR\n" +
+	"deliveries2\xa5\x05\n" +
 	"\x0fReminderService\x12M\n" +
 	"\x0eCreateReminder\x12\x1f.reminder.CreateReminderRequest\x1a\x1a.reminder.ReminderResponse\x12M\n" +
 	"\fGetReminders\x12\x1d.reminder.GetRemindersRequest\x1a\x1e.reminder.GetRemindersResponse\x12G\n" +
 	"\vGetReminder\x12\x1c.reminder.GetReminderRequest\x1a\x1a.reminder.ReminderResponse\x12M\n" +
 	"\x0eUpdateReminder\x12\x1f.reminder.UpdateReminderRequest\x1a\x1a.reminder.ReminderResponse\x12S\n" +
-	"\x0eDeleteReminder\x12\x1f.reminder.DeleteReminderRequest\x1a .reminder.DeleteReminderResponseB:Z8github.com/kiribu/jwt-practice/internal/reminder/grpc/pbb\x06proto3"
+	"\x0eDeleteReminder\x12\x1f.reminder.DeleteReminderRequest\x1a .reminder.DeleteReminderResponse\x12M\n" +
+	"\x0eSnoozeReminder\x12\x1f.reminder.SnoozeReminderRequest\x1a\x1a.reminder.ReminderResponse\x12W\n" +
+	"\x13AcknowledgeReminder\x12$.reminder.AcknowledgeReminderRequest\x1a\x1a.reminder.ReminderResponse\x12_\n" +
+	"\x12GetDeliveryHistory\x12#.reminder.GetDeliveryHistoryRequest\x1a$.reminder.GetDeliveryHistoryResponseB:Z8github.com/kiribu/jwt-practice/internal/reminder/grpc/pbb\x06proto3"
 
 var (
 	file_proto_reminder_proto_rawDescOnce sync.Once
@@ -577,34 +966,46 @@ func file_proto_reminder_proto_rawDescGZIP() []byte {
 	return file_proto_reminder_proto_rawDescData
 }
 
-var file_proto_reminder_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_proto_reminder_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_proto_reminder_proto_goTypes = []any{
-	(*CreateReminderRequest)(nil),  // 0: reminder.CreateReminderRequest
-	(*GetRemindersRequest)(nil),    // 1: reminder.GetRemindersRequest
-	(*GetReminderRequest)(nil),     // 2: reminder.GetReminderRequest
-	(*UpdateReminderRequest)(nil),  // 3: reminder.UpdateReminderRequest
-	(*DeleteReminderRequest)(nil),  // 4: reminder.DeleteReminderRequest
-	(*ReminderResponse)(nil),       // 5: reminder.ReminderResponse
-	(*GetRemindersResponse)(nil),   // 6: reminder.GetRemindersResponse
-	(*DeleteReminderResponse)(nil), // 7: reminder.DeleteReminderResponse
+	(*CreateReminderRequest)(nil),      // 0: reminder.CreateReminderRequest
+	(*GetRemindersRequest)(nil),        // 1: reminder.GetRemindersRequest
+	(*GetReminderRequest)(nil),         // 2: reminder.GetReminderRequest
+	(*UpdateReminderRequest)(nil),      // 3: reminder.UpdateReminderRequest
+	(*DeleteReminderRequest)(nil),      // 4: reminder.DeleteReminderRequest
+	(*SnoozeReminderRequest)(nil),      // 5: reminder.SnoozeReminderRequest
+	(*AcknowledgeReminderRequest)(nil), // 6: reminder.AcknowledgeReminderRequest
+	(*ReminderResponse)(nil),           // 7: reminder.ReminderResponse
+	(*GetRemindersResponse)(nil),       // 8: reminder.GetRemindersResponse
+	(*DeleteReminderResponse)(nil),     // 9: reminder.DeleteReminderResponse
+	(*GetDeliveryHistoryRequest)(nil),  // 10: reminder.GetDeliveryHistoryRequest
+	(*DeliveryAttempt)(nil),            // 11: reminder.DeliveryAttempt
+	(*GetDeliveryHistoryResponse)(nil), // 12: reminder.GetDeliveryHistoryResponse
 }
 var file_proto_reminder_proto_depIdxs = []int32{
-	5, // 0: reminder.GetRemindersResponse.reminders:type_name -> reminder.ReminderResponse
-	0, // 1: reminder.ReminderService.CreateReminder:input_type -> reminder.CreateReminderRequest
-	1, // 2: reminder.ReminderService.GetReminders:input_type -> reminder.GetRemindersRequest
-	2, // 3: reminder.ReminderService.GetReminder:input_type -> reminder.GetReminderRequest
-	3, // 4: reminder.ReminderService.UpdateReminder:input_type -> reminder.UpdateReminderRequest
-	4, // 5: reminder.ReminderService.DeleteReminder:input_type -> reminder.DeleteReminderRequest
-	5, // 6: reminder.ReminderService.CreateReminder:output_type -> reminder.ReminderResponse
-	6, // 7: reminder.ReminderService.GetReminders:output_type -> reminder.GetRemindersResponse
-	5, // 8: reminder.ReminderService.GetReminder:output_type -> reminder.ReminderResponse
-	5, // 9: reminder.ReminderService.UpdateReminder:output_type -> reminder.ReminderResponse
-	7, // 10: reminder.ReminderService.DeleteReminder:output_type -> reminder.DeleteReminderResponse
-	6, // [6:11] is the sub-list for method output_type
-	1, // [1:6] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	7,  // 0: reminder.GetRemindersResponse.reminders:type_name -> reminder.ReminderResponse
+	11, // 1: reminder.GetDeliveryHistoryResponse.deliveries:type_name -> reminder.DeliveryAttempt
+	0,  // 2: reminder.ReminderService.CreateReminder:input_type -> reminder.CreateReminderRequest
+	1,  // 3: reminder.ReminderService.GetReminders:input_type -> reminder.GetRemindersRequest
+	2,  // 4: reminder.ReminderService.GetReminder:input_type -> reminder.GetReminderRequest
+	3,  // 5: reminder.ReminderService.UpdateReminder:input_type -> reminder.UpdateReminderRequest
+	4,  // 6: reminder.ReminderService.DeleteReminder:input_type -> reminder.DeleteReminderRequest
+	5,  // 7: reminder.ReminderService.SnoozeReminder:input_type -> reminder.SnoozeReminderRequest
+	6,  // 8: reminder.ReminderService.AcknowledgeReminder:input_type -> reminder.AcknowledgeReminderRequest
+	10, // 9: reminder.ReminderService.GetDeliveryHistory:input_type -> reminder.GetDeliveryHistoryRequest
+	7,  // 10: reminder.ReminderService.CreateReminder:output_type -> reminder.ReminderResponse
+	8,  // 11: reminder.ReminderService.GetReminders:output_type -> reminder.GetRemindersResponse
+	7,  // 12: reminder.ReminderService.GetReminder:output_type -> reminder.ReminderResponse
+	7,  // 13: reminder.ReminderService.UpdateReminder:output_type -> reminder.ReminderResponse
+	9,  // 14: reminder.ReminderService.DeleteReminder:output_type -> reminder.DeleteReminderResponse
+	7,  // 15: reminder.ReminderService.SnoozeReminder:output_type -> reminder.ReminderResponse
+	7,  // 16: reminder.ReminderService.AcknowledgeReminder:output_type -> reminder.ReminderResponse
+	12, // 17: reminder.ReminderService.GetDeliveryHistory:output_type -> reminder.GetDeliveryHistoryResponse
+	10, // [10:18] is the sub-list for method output_type
+	2,  // [2:10] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_proto_reminder_proto_init() }
@@ -618,7 +1019,7 @@ func file_proto_reminder_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_reminder_proto_rawDesc), len(file_proto_reminder_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
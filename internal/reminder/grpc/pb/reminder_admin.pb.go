@@ -0,0 +1,657 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: proto/reminder_admin.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListFailedEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"` // defaults to 50
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFailedEventsRequest) Reset() {
+	*x = ListFailedEventsRequest{}
+	mi := &file_proto_reminder_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFailedEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFailedEventsRequest) ProtoMessage() {}
+
+func (x *ListFailedEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reminder_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFailedEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListFailedEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reminder_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListFailedEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListFailedEventsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type OutboxEventResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	EventType     string                 `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	ReminderId    string                 `protobuf:"bytes,3,opt,name=reminder_id,json=reminderId,proto3" json:"reminder_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Payload       string                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"` // raw JSON
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	RetryCount    int32                  `protobuf:"varint,7,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,8,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OutboxEventResponse) Reset() {
+	*x = OutboxEventResponse{}
+	mi := &file_proto_reminder_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutboxEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutboxEventResponse) ProtoMessage() {}
+
+func (x *OutboxEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reminder_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutboxEventResponse.ProtoReflect.Descriptor instead.
+func (*OutboxEventResponse) Descriptor() ([]byte, []int) {
+	return file_proto_reminder_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OutboxEventResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OutboxEventResponse) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *OutboxEventResponse) GetReminderId() string {
+	if x != nil {
+		return x.ReminderId
+	}
+	return ""
+}
+
+func (x *OutboxEventResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *OutboxEventResponse) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *OutboxEventResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *OutboxEventResponse) GetRetryCount() int32 {
+	if x != nil {
+		return x.RetryCount
+	}
+	return 0
+}
+
+func (x *OutboxEventResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *OutboxEventResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ListFailedEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*OutboxEventResponse `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFailedEventsResponse) Reset() {
+	*x = ListFailedEventsResponse{}
+	mi := &file_proto_reminder_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFailedEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFailedEventsResponse) ProtoMessage() {}
+
+func (x *ListFailedEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reminder_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFailedEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListFailedEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_reminder_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListFailedEventsResponse) GetEvents() []*OutboxEventResponse {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type GetEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventRequest) Reset() {
+	*x = GetEventRequest{}
+	mi := &file_proto_reminder_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventRequest) ProtoMessage() {}
+
+func (x *GetEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reminder_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventRequest.ProtoReflect.Descriptor instead.
+func (*GetEventRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reminder_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetEventRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ResolveEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	Actor         string                 `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"` // who performed the action, written to the audit log
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveEventsRequest) Reset() {
+	*x = ResolveEventsRequest{}
+	mi := &file_proto_reminder_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveEventsRequest) ProtoMessage() {}
+
+func (x *ResolveEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reminder_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveEventsRequest.ProtoReflect.Descriptor instead.
+func (*ResolveEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reminder_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ResolveEventsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *ResolveEventsRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+type ResolveEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"` // events that were FAILED and got resolved; others are skipped
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveEventsResponse) Reset() {
+	*x = ResolveEventsResponse{}
+	mi := &file_proto_reminder_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveEventsResponse) ProtoMessage() {}
+
+func (x *ResolveEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reminder_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveEventsResponse.ProtoReflect.Descriptor instead.
+func (*ResolveEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_reminder_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ResolveEventsResponse) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type ListAuditLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"` // defaults to 50
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditLogRequest) Reset() {
+	*x = ListAuditLogRequest{}
+	mi := &file_proto_reminder_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditLogRequest) ProtoMessage() {}
+
+func (x *ListAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reminder_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reminder_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListAuditLogRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListAuditLogRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type AuditEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	EventId       string                 `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Actor         string                 `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_proto_reminder_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reminder_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_proto_reminder_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AuditEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ListAuditLogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*AuditEntry          `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditLogResponse) Reset() {
+	*x = ListAuditLogResponse{}
+	mi := &file_proto_reminder_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditLogResponse) ProtoMessage() {}
+
+func (x *ListAuditLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reminder_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditLogResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditLogResponse) Descriptor() ([]byte, []int) {
+	return file_proto_reminder_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListAuditLogResponse) GetEntries() []*AuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+var File_proto_reminder_admin_proto protoreflect.FileDescriptor
+
+const file_proto_reminder_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x1aproto/reminder_admin.proto\x12\breminder\"G\n" +
+	"\x17ListFailedEventsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\"\x95\x02\n" +
+	"\x13OutboxEventResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\tR\teventType\x12\x1f\n" +
+	"\vreminder_id\x18\x03 \x01(\tR\n" +
+	"reminderId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x18\n" +
+	"\apayload\x18\x05 \x01(\tR\apayload\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1f\n" +
+	"\vretry_count\x18\a \x01(\x05R\n" +
+	"retryCount\x12#\n" +
+	"\rerror_message\x18\b \x01(\tR\ferrorMessage\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\"Q\n" +
+	"\x18ListFailedEventsResponse\x125\n" +
+	"\x06events\x18\x01 \x03(\v2\x1d.reminder.OutboxEventResponseR\x06events\"!\n" +
+	"\x0fGetEventRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\">\n" +
+	"\x14ResolveEventsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12\x14\n" +
+	"\x05actor\x18\x02 \x01(\tR\x05actor\")\n" +
+	"\x15ResolveEventsResponse\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"C\n" +
+	"\x13ListAuditLogRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\"\x84\x01\n" +
+	"\n" +
+	"AuditEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\tR\aeventId\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\"F\n" +
+	"\x14ListAuditLogResponse\x12.\n" +
+	"\aentries\x18\x01 \x03(\v2\x14.reminder.AuditEntryR\aentries2\xaa\x03\n" +
+	"\x14ReminderAdminService\x12Y\n" +
+	"\x10ListFailedEvents\x12!.reminder.ListFailedEventsRequest\x1a\".reminder.ListFailedEventsResponse\x12D\n" +
+	"\bGetEvent\x12\x19.reminder.GetEventRequest\x1a\x1d.reminder.OutboxEventResponse\x12P\n" +
+	"\rRequeueEvents\x12\x1e.reminder.ResolveEventsRequest\x1a\x1f.reminder.ResolveEventsResponse\x12P\n" +
+	"\rDiscardEvents\x12\x1e.reminder.ResolveEventsRequest\x1a\x1f.reminder.ResolveEventsResponse\x12M\n" +
+	"\fListAuditLog\x12\x1d.reminder.ListAuditLogRequest\x1a\x1e.reminder.ListAuditLogResponseB:Z8github.com/kiribu/jwt-practice/internal/reminder/grpc/pbb\x06proto3"
+
+var (
+	file_proto_reminder_admin_proto_rawDescOnce sync.Once
+	file_proto_reminder_admin_proto_rawDescData []byte
+)
+
+func file_proto_reminder_admin_proto_rawDescGZIP() []byte {
+	file_proto_reminder_admin_proto_rawDescOnce.Do(func() {
+		file_proto_reminder_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_reminder_admin_proto_rawDesc), len(file_proto_reminder_admin_proto_rawDesc)))
+	})
+	return file_proto_reminder_admin_proto_rawDescData
+}
+
+var file_proto_reminder_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_proto_reminder_admin_proto_goTypes = []any{
+	(*ListFailedEventsRequest)(nil),  // 0: reminder.ListFailedEventsRequest
+	(*OutboxEventResponse)(nil),      // 1: reminder.OutboxEventResponse
+	(*ListFailedEventsResponse)(nil), // 2: reminder.ListFailedEventsResponse
+	(*GetEventRequest)(nil),          // 3: reminder.GetEventRequest
+	(*ResolveEventsRequest)(nil),     // 4: reminder.ResolveEventsRequest
+	(*ResolveEventsResponse)(nil),    // 5: reminder.ResolveEventsResponse
+	(*ListAuditLogRequest)(nil),      // 6: reminder.ListAuditLogRequest
+	(*AuditEntry)(nil),               // 7: reminder.AuditEntry
+	(*ListAuditLogResponse)(nil),     // 8: reminder.ListAuditLogResponse
+}
+var file_proto_reminder_admin_proto_depIdxs = []int32{
+	1, // 0: reminder.ListFailedEventsResponse.events:type_name -> reminder.OutboxEventResponse
+	7, // 1: reminder.ListAuditLogResponse.entries:type_name -> reminder.AuditEntry
+	0, // 2: reminder.ReminderAdminService.ListFailedEvents:input_type -> reminder.ListFailedEventsRequest
+	3, // 3: reminder.ReminderAdminService.GetEvent:input_type -> reminder.GetEventRequest
+	4, // 4: reminder.ReminderAdminService.RequeueEvents:input_type -> reminder.ResolveEventsRequest
+	4, // 5: reminder.ReminderAdminService.DiscardEvents:input_type -> reminder.ResolveEventsRequest
+	6, // 6: reminder.ReminderAdminService.ListAuditLog:input_type -> reminder.ListAuditLogRequest
+	2, // 7: reminder.ReminderAdminService.ListFailedEvents:output_type -> reminder.ListFailedEventsResponse
+	1, // 8: reminder.ReminderAdminService.GetEvent:output_type -> reminder.OutboxEventResponse
+	5, // 9: reminder.ReminderAdminService.RequeueEvents:output_type -> reminder.ResolveEventsResponse
+	5, // 10: reminder.ReminderAdminService.DiscardEvents:output_type -> reminder.ResolveEventsResponse
+	8, // 11: reminder.ReminderAdminService.ListAuditLog:output_type -> reminder.ListAuditLogResponse
+	7, // [7:12] is the sub-list for method output_type
+	2, // [2:7] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_proto_reminder_admin_proto_init() }
+func file_proto_reminder_admin_proto_init() {
+	if File_proto_reminder_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_reminder_admin_proto_rawDesc), len(file_proto_reminder_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_reminder_admin_proto_goTypes,
+		DependencyIndexes: file_proto_reminder_admin_proto_depIdxs,
+		MessageInfos:      file_proto_reminder_admin_proto_msgTypes,
+	}.Build()
+	File_proto_reminder_admin_proto = out.File
+	file_proto_reminder_admin_proto_goTypes = nil
+	file_proto_reminder_admin_proto_depIdxs = nil
+}
@@ -0,0 +1,279 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v3.21.12
+// source: proto/reminder_admin.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ReminderAdminService_ListFailedEvents_FullMethodName = "/reminder.ReminderAdminService/ListFailedEvents"
+	ReminderAdminService_GetEvent_FullMethodName         = "/reminder.ReminderAdminService/GetEvent"
+	ReminderAdminService_RequeueEvents_FullMethodName    = "/reminder.ReminderAdminService/RequeueEvents"
+	ReminderAdminService_DiscardEvents_FullMethodName    = "/reminder.ReminderAdminService/DiscardEvents"
+	ReminderAdminService_ListAuditLog_FullMethodName     = "/reminder.ReminderAdminService/ListAuditLog"
+)
+
+// ReminderAdminServiceClient is the client API for ReminderAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ReminderAdminService manages outbox events that exhausted their retries.
+// Callers are trusted to have checked admin rights (the API Gateway does).
+type ReminderAdminServiceClient interface {
+	ListFailedEvents(ctx context.Context, in *ListFailedEventsRequest, opts ...grpc.CallOption) (*ListFailedEventsResponse, error)
+	GetEvent(ctx context.Context, in *GetEventRequest, opts ...grpc.CallOption) (*OutboxEventResponse, error)
+	RequeueEvents(ctx context.Context, in *ResolveEventsRequest, opts ...grpc.CallOption) (*ResolveEventsResponse, error)
+	DiscardEvents(ctx context.Context, in *ResolveEventsRequest, opts ...grpc.CallOption) (*ResolveEventsResponse, error)
+	ListAuditLog(ctx context.Context, in *ListAuditLogRequest, opts ...grpc.CallOption) (*ListAuditLogResponse, error)
+}
+
+type reminderAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReminderAdminServiceClient(cc grpc.ClientConnInterface) ReminderAdminServiceClient {
+	return &reminderAdminServiceClient{cc}
+}
+
+func (c *reminderAdminServiceClient) ListFailedEvents(ctx context.Context, in *ListFailedEventsRequest, opts ...grpc.CallOption) (*ListFailedEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFailedEventsResponse)
+	err := c.cc.Invoke(ctx, ReminderAdminService_ListFailedEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reminderAdminServiceClient) GetEvent(ctx context.Context, in *GetEventRequest, opts ...grpc.CallOption) (*OutboxEventResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OutboxEventResponse)
+	err := c.cc.Invoke(ctx, ReminderAdminService_GetEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reminderAdminServiceClient) RequeueEvents(ctx context.Context, in *ResolveEventsRequest, opts ...grpc.CallOption) (*ResolveEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveEventsResponse)
+	err := c.cc.Invoke(ctx, ReminderAdminService_RequeueEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reminderAdminServiceClient) DiscardEvents(ctx context.Context, in *ResolveEventsRequest, opts ...grpc.CallOption) (*ResolveEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveEventsResponse)
+	err := c.cc.Invoke(ctx, ReminderAdminService_DiscardEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reminderAdminServiceClient) ListAuditLog(ctx context.Context, in *ListAuditLogRequest, opts ...grpc.CallOption) (*ListAuditLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditLogResponse)
+	err := c.cc.Invoke(ctx, ReminderAdminService_ListAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReminderAdminServiceServer is the server API for ReminderAdminService service.
+// All implementations must embed UnimplementedReminderAdminServiceServer
+// for forward compatibility.
+//
+// ReminderAdminService manages outbox events that exhausted their retries.
+// Callers are trusted to have checked admin rights (the API Gateway does).
+type ReminderAdminServiceServer interface {
+	ListFailedEvents(context.Context, *ListFailedEventsRequest) (*ListFailedEventsResponse, error)
+	GetEvent(context.Context, *GetEventRequest) (*OutboxEventResponse, error)
+	RequeueEvents(context.Context, *ResolveEventsRequest) (*ResolveEventsResponse, error)
+	DiscardEvents(context.Context, *ResolveEventsRequest) (*ResolveEventsResponse, error)
+	ListAuditLog(context.Context, *ListAuditLogRequest) (*ListAuditLogResponse, error)
+	mustEmbedUnimplementedReminderAdminServiceServer()
+}
+
+// UnimplementedReminderAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReminderAdminServiceServer struct{}
+
+func (UnimplementedReminderAdminServiceServer) ListFailedEvents(context.Context, *ListFailedEventsRequest) (*ListFailedEventsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListFailedEvents not implemented")
+}
+func (UnimplementedReminderAdminServiceServer) GetEvent(context.Context, *GetEventRequest) (*OutboxEventResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetEvent not implemented")
+}
+func (UnimplementedReminderAdminServiceServer) RequeueEvents(context.Context, *ResolveEventsRequest) (*ResolveEventsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RequeueEvents not implemented")
+}
+func (UnimplementedReminderAdminServiceServer) DiscardEvents(context.Context, *ResolveEventsRequest) (*ResolveEventsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DiscardEvents not implemented")
+}
+func (UnimplementedReminderAdminServiceServer) ListAuditLog(context.Context, *ListAuditLogRequest) (*ListAuditLogResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAuditLog not implemented")
+}
+func (UnimplementedReminderAdminServiceServer) mustEmbedUnimplementedReminderAdminServiceServer() {}
+func (UnimplementedReminderAdminServiceServer) testEmbeddedByValue()                              {}
+
+// UnsafeReminderAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReminderAdminServiceServer will
+// result in compilation errors.
+type UnsafeReminderAdminServiceServer interface {
+	mustEmbedUnimplementedReminderAdminServiceServer()
+}
+
+func RegisterReminderAdminServiceServer(s grpc.ServiceRegistrar, srv ReminderAdminServiceServer) {
+	// If the following call panics, it indicates UnimplementedReminderAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ReminderAdminService_ServiceDesc, srv)
+}
+
+func _ReminderAdminService_ListFailedEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFailedEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReminderAdminServiceServer).ListFailedEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReminderAdminService_ListFailedEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReminderAdminServiceServer).ListFailedEvents(ctx, req.(*ListFailedEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReminderAdminService_GetEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReminderAdminServiceServer).GetEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReminderAdminService_GetEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReminderAdminServiceServer).GetEvent(ctx, req.(*GetEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReminderAdminService_RequeueEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReminderAdminServiceServer).RequeueEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReminderAdminService_RequeueEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReminderAdminServiceServer).RequeueEvents(ctx, req.(*ResolveEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReminderAdminService_DiscardEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReminderAdminServiceServer).DiscardEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReminderAdminService_DiscardEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReminderAdminServiceServer).DiscardEvents(ctx, req.(*ResolveEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReminderAdminService_ListAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReminderAdminServiceServer).ListAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReminderAdminService_ListAuditLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReminderAdminServiceServer).ListAuditLog(ctx, req.(*ListAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReminderAdminService_ServiceDesc is the grpc.ServiceDesc for ReminderAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReminderAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "reminder.ReminderAdminService",
+	HandlerType: (*ReminderAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListFailedEvents",
+			Handler:    _ReminderAdminService_ListFailedEvents_Handler,
+		},
+		{
+			MethodName: "GetEvent",
+			Handler:    _ReminderAdminService_GetEvent_Handler,
+		},
+		{
+			MethodName: "RequeueEvents",
+			Handler:    _ReminderAdminService_RequeueEvents_Handler,
+		},
+		{
+			MethodName: "DiscardEvents",
+			Handler:    _ReminderAdminService_DiscardEvents_Handler,
+		},
+		{
+			MethodName: "ListAuditLog",
+			Handler:    _ReminderAdminService_ListAuditLog_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/reminder_admin.proto",
+}
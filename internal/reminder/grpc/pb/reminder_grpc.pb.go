@@ -19,11 +19,14 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ReminderService_CreateReminder_FullMethodName = "/reminder.ReminderService/CreateReminder"
-	ReminderService_GetReminders_FullMethodName   = "/reminder.ReminderService/GetReminders"
-	ReminderService_GetReminder_FullMethodName    = "/reminder.ReminderService/GetReminder"
-	ReminderService_UpdateReminder_FullMethodName = "/reminder.ReminderService/UpdateReminder"
-	ReminderService_DeleteReminder_FullMethodName = "/reminder.ReminderService/DeleteReminder"
+	ReminderService_CreateReminder_FullMethodName      = "/reminder.ReminderService/CreateReminder"
+	ReminderService_GetReminders_FullMethodName        = "/reminder.ReminderService/GetReminders"
+	ReminderService_GetReminder_FullMethodName         = "/reminder.ReminderService/GetReminder"
+	ReminderService_UpdateReminder_FullMethodName      = "/reminder.ReminderService/UpdateReminder"
+	ReminderService_DeleteReminder_FullMethodName      = "/reminder.ReminderService/DeleteReminder"
+	ReminderService_SnoozeReminder_FullMethodName      = "/reminder.ReminderService/SnoozeReminder"
+	ReminderService_AcknowledgeReminder_FullMethodName = "/reminder.ReminderService/AcknowledgeReminder"
+	ReminderService_GetDeliveryHistory_FullMethodName  = "/reminder.ReminderService/GetDeliveryHistory"
 )
 
 // ReminderServiceClient is the client API for ReminderService service.
@@ -35,6 +38,9 @@ type ReminderServiceClient interface {
 	GetReminder(ctx context.Context, in *GetReminderRequest, opts ...grpc.CallOption) (*ReminderResponse, error)
 	UpdateReminder(ctx context.Context, in *UpdateReminderRequest, opts ...grpc.CallOption) (*ReminderResponse, error)
 	DeleteReminder(ctx context.Context, in *DeleteReminderRequest, opts ...grpc.CallOption) (*DeleteReminderResponse, error)
+	SnoozeReminder(ctx context.Context, in *SnoozeReminderRequest, opts ...grpc.CallOption) (*ReminderResponse, error)
+	AcknowledgeReminder(ctx context.Context, in *AcknowledgeReminderRequest, opts ...grpc.CallOption) (*ReminderResponse, error)
+	GetDeliveryHistory(ctx context.Context, in *GetDeliveryHistoryRequest, opts ...grpc.CallOption) (*GetDeliveryHistoryResponse, error)
 }
 
 type reminderServiceClient struct {
@@ -95,6 +101,36 @@ func (c *reminderServiceClient) DeleteReminder(ctx context.Context, in *DeleteRe
 	return out, nil
 }
 
+func (c *reminderServiceClient) SnoozeReminder(ctx context.Context, in *SnoozeReminderRequest, opts ...grpc.CallOption) (*ReminderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReminderResponse)
+	err := c.cc.Invoke(ctx, ReminderService_SnoozeReminder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reminderServiceClient) AcknowledgeReminder(ctx context.Context, in *AcknowledgeReminderRequest, opts ...grpc.CallOption) (*ReminderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReminderResponse)
+	err := c.cc.Invoke(ctx, ReminderService_AcknowledgeReminder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reminderServiceClient) GetDeliveryHistory(ctx context.Context, in *GetDeliveryHistoryRequest, opts ...grpc.CallOption) (*GetDeliveryHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDeliveryHistoryResponse)
+	err := c.cc.Invoke(ctx, ReminderService_GetDeliveryHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ReminderServiceServer is the server API for ReminderService service.
 // All implementations must embed UnimplementedReminderServiceServer
 // for forward compatibility.
@@ -104,6 +140,9 @@ type ReminderServiceServer interface {
 	GetReminder(context.Context, *GetReminderRequest) (*ReminderResponse, error)
 	UpdateReminder(context.Context, *UpdateReminderRequest) (*ReminderResponse, error)
 	DeleteReminder(context.Context, *DeleteReminderRequest) (*DeleteReminderResponse, error)
+	SnoozeReminder(context.Context, *SnoozeReminderRequest) (*ReminderResponse, error)
+	AcknowledgeReminder(context.Context, *AcknowledgeReminderRequest) (*ReminderResponse, error)
+	GetDeliveryHistory(context.Context, *GetDeliveryHistoryRequest) (*GetDeliveryHistoryResponse, error)
 	mustEmbedUnimplementedReminderServiceServer()
 }
 
@@ -129,6 +168,15 @@ func (UnimplementedReminderServiceServer) UpdateReminder(context.Context, *Updat
 func (UnimplementedReminderServiceServer) DeleteReminder(context.Context, *DeleteReminderRequest) (*DeleteReminderResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteReminder not implemented")
 }
+func (UnimplementedReminderServiceServer) SnoozeReminder(context.Context, *SnoozeReminderRequest) (*ReminderResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SnoozeReminder not implemented")
+}
+func (UnimplementedReminderServiceServer) AcknowledgeReminder(context.Context, *AcknowledgeReminderRequest) (*ReminderResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AcknowledgeReminder not implemented")
+}
+func (UnimplementedReminderServiceServer) GetDeliveryHistory(context.Context, *GetDeliveryHistoryRequest) (*GetDeliveryHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDeliveryHistory not implemented")
+}
 func (UnimplementedReminderServiceServer) mustEmbedUnimplementedReminderServiceServer() {}
 func (UnimplementedReminderServiceServer) testEmbeddedByValue()                         {}
 
@@ -240,6 +288,60 @@ func _ReminderService_DeleteReminder_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ReminderService_SnoozeReminder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnoozeReminderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReminderServiceServer).SnoozeReminder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReminderService_SnoozeReminder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReminderServiceServer).SnoozeReminder(ctx, req.(*SnoozeReminderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReminderService_AcknowledgeReminder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcknowledgeReminderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReminderServiceServer).AcknowledgeReminder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReminderService_AcknowledgeReminder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReminderServiceServer).AcknowledgeReminder(ctx, req.(*AcknowledgeReminderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReminderService_GetDeliveryHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeliveryHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReminderServiceServer).GetDeliveryHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReminderService_GetDeliveryHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReminderServiceServer).GetDeliveryHistory(ctx, req.(*GetDeliveryHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ReminderService_ServiceDesc is the grpc.ServiceDesc for ReminderService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,6 +369,18 @@ var ReminderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteReminder",
 			Handler:    _ReminderService_DeleteReminder_Handler,
 		},
+		{
+			MethodName: "SnoozeReminder",
+			Handler:    _ReminderService_SnoozeReminder_Handler,
+		},
+		{
+			MethodName: "AcknowledgeReminder",
+			Handler:    _ReminderService_AcknowledgeReminder_Handler,
+		},
+		{
+			MethodName: "GetDeliveryHistory",
+			Handler:    _ReminderService_GetDeliveryHistory_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/reminder.proto",
@@ -2,10 +2,12 @@ package remindergrpc
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 	"github.com/kiribu/jwt-practice/internal/reminder/grpc/pb"
 	"github.com/kiribu/jwt-practice/internal/reminder/service"
+	"github.com/kiribu/jwt-practice/internal/reminder/storage"
 	"github.com/kiribu/jwt-practice/models"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -26,7 +28,7 @@ func (s *ReminderServer) CreateReminder(ctx context.Context, req *pb.CreateRemin
 		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
 	}
 
-	reminder, err := s.service.Create(ctx, userID, req.Title, req.Description, req.RemindAt)
+	reminder, err := s.service.Create(ctx, userID, req.Title, req.Description, req.RemindAt, req.Rrule, req.Timezone)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -81,7 +83,7 @@ func (s *ReminderServer) UpdateReminder(ctx context.Context, req *pb.UpdateRemin
 		return nil, status.Errorf(codes.InvalidArgument, "invalid id: %v", err)
 	}
 
-	reminder, err := s.service.Update(ctx, userID, id, req.Title, req.Description, req.RemindAt)
+	reminder, err := s.service.Update(ctx, userID, id, req.Title, req.Description, req.RemindAt, req.Rrule, req.Timezone)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -119,15 +121,104 @@ func (s *ReminderServer) DeleteReminder(ctx context.Context, req *pb.DeleteRemin
 	}, nil
 }
 
+func (s *ReminderServer) SnoozeReminder(ctx context.Context, req *pb.SnoozeReminderRequest) (*pb.ReminderResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
+	}
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid id: %v", err)
+	}
+
+	reminder, err := s.service.Snooze(ctx, userID, id, req.Duration, req.RemindAt)
+	if err != nil {
+		return nil, actionError(err)
+	}
+
+	return toProtoReminder(reminder), nil
+}
+
+func (s *ReminderServer) AcknowledgeReminder(ctx context.Context, req *pb.AcknowledgeReminderRequest) (*pb.ReminderResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
+	}
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid id: %v", err)
+	}
+
+	reminder, err := s.service.Acknowledge(ctx, userID, id)
+	if err != nil {
+		return nil, actionError(err)
+	}
+
+	return toProtoReminder(reminder), nil
+}
+
+func (s *ReminderServer) GetDeliveryHistory(ctx context.Context, req *pb.GetDeliveryHistoryRequest) (*pb.GetDeliveryHistoryResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
+	}
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid id: %v", err)
+	}
+
+	attempts, err := s.service.GetDeliveryHistory(ctx, userID, id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	var deliveries []*pb.DeliveryAttempt
+	for _, a := range attempts {
+		deliveries = append(deliveries, &pb.DeliveryAttempt{
+			Id:          a.ID.String(),
+			Status:      a.Status,
+			Channel:     a.Channel,
+			Reason:      a.Reason,
+			AttemptedAt: a.AttemptedAt.Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+
+	return &pb.GetDeliveryHistoryResponse{Deliveries: deliveries}, nil
+}
+
+// actionError maps errors of status-changing actions to gRPC codes. Only
+// transitions the reminder's state does not allow are FailedPrecondition.
+func actionError(err error) error {
+	var inputErr service.InputError
+	var transitionErr service.TransitionError
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.As(err, &inputErr):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.As(err, &transitionErr), errors.Is(err, storage.ErrStatusChanged):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
 func toProtoReminder(r *models.Reminder) *pb.ReminderResponse {
-	return &pb.ReminderResponse{
+	resp := &pb.ReminderResponse{
 		Id:          r.ID.String(),     // UUID to string
 		UserId:      r.UserID.String(), // UUID to string
 		Title:       r.Title,
 		Description: r.Description,
 		RemindAt:    r.RemindAt.Format("2006-01-02T15:04:05Z07:00"),
-		IsSent:      r.IsSent,
+		IsSent:      r.Status != models.ReminderStatusScheduled && r.Status != models.ReminderStatusCancelled,
+		Status:      string(r.Status),
 		CreatedAt:   r.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:   r.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Rrule:       r.RRule,
+		Timezone:    r.Timezone,
+	}
+	if r.AcknowledgedAt != nil {
+		resp.AcknowledgedAt = r.AcknowledgedAt.Format("2006-01-02T15:04:05Z07:00")
 	}
+	return resp
 }
@@ -0,0 +1,34 @@
+package remindergrpc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/kiribu/jwt-practice/internal/reminder/service"
+	"github.com/kiribu/jwt-practice/internal/reminder/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestActionError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"not found", storage.ErrNotFound, codes.NotFound},
+		{"bad duration", service.InputError("duration must be positive"), codes.InvalidArgument},
+		{"illegal transition", service.ValidateTransition("cancelled", "scheduled"), codes.FailedPrecondition},
+		{"concurrent change", fmt.Errorf("snooze: %w", storage.ErrStatusChanged), codes.FailedPrecondition},
+		{"storage failure", errors.New("connection refused"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(actionError(tt.err)); got != tt.want {
+				t.Errorf("code = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
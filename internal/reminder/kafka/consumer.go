@@ -0,0 +1,172 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/kiribu/jwt-practice/internal/reminder/service"
+	"github.com/kiribu/jwt-practice/internal/reminder/storage"
+	"github.com/kiribu/jwt-practice/models"
+	"github.com/kiribu/jwt-practice/pkg/correlation"
+	"github.com/kiribu/jwt-practice/pkg/events"
+	"github.com/kiribu/jwt-practice/pkg/metrics"
+	"github.com/kiribu/jwt-practice/pkg/tracing"
+	"github.com/segmentio/kafka-go"
+)
+
+// Delivery receipts that fail to record are retried with these delays until
+// they succeed, so a storage outage pauses the partition instead of losing them.
+const (
+	resultRetryBase = 500 * time.Millisecond
+	resultRetryMax  = 30 * time.Second
+)
+
+// messageReader is the part of *kafka.Reader the consumer uses.
+type messageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Config() kafka.ReaderConfig
+	Close() error
+}
+
+// ResultConsumer reads delivery receipts published by notification-service.
+type ResultConsumer struct {
+	reader    messageReader
+	service   *service.ReminderService
+	retryBase time.Duration
+	retryMax  time.Duration
+}
+
+func NewResultConsumer(brokers []string, topic, groupID string, service *service.ReminderService) *ResultConsumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     brokers,
+		Topic:       topic,
+		GroupID:     groupID,
+		MinBytes:    10e3, // 10KB
+		MaxBytes:    10e6, // 10MB
+		StartOffset: kafka.FirstOffset,
+	})
+
+	return &ResultConsumer{
+		reader:    reader,
+		service:   service,
+		retryBase: resultRetryBase,
+		retryMax:  resultRetryMax,
+	}
+}
+
+func (c *ResultConsumer) Start(ctx context.Context) {
+	slog.Info("Starting delivery result consumer", "topic", c.reader.Config().Topic)
+	defer c.reader.Close()
+
+	for {
+		m, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				slog.Info("Stopping delivery result consumer...")
+				return
+			}
+			slog.Error("Error reading message", "error", err)
+			time.Sleep(1 * time.Second)
+			continue
+		}
+
+		// Committing past a receipt that was not recorded would lose it for good
+		if !c.processMessage(ctx, m) {
+			slog.Info("Stopping delivery result consumer...")
+			return
+		}
+
+		if err := c.reader.CommitMessages(ctx, m); err != nil {
+			slog.Error("Error committing message", "error", err, "partition", m.Partition, "offset", m.Offset)
+			metrics.ConsumerError(m, c.reader.Config().GroupID, metrics.ReasonCommit)
+		}
+	}
+}
+
+// processMessage retries m until it is handled, with exponential backoff.
+// It returns false only when ctx is done, leaving m uncommitted.
+func (c *ResultConsumer) processMessage(ctx context.Context, m kafka.Message) bool {
+	delay := c.retryBase
+	for attempt := 1; ; attempt++ {
+		err := c.handleMessage(ctx, m)
+		if err == nil {
+			return true
+		}
+
+		metrics.ConsumerError(m, c.reader.Config().GroupID, metrics.ReasonProcess)
+		slog.Warn("Retrying delivery result", "partition", m.Partition, "offset", m.Offset, "attempt", attempt, "retry_in", delay, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, c.retryMax)
+	}
+}
+
+// handleMessage records one delivery receipt. It returns an error only when a
+// retry may succeed; malformed receipts and receipts for unknown reminders can
+// never be recorded, so they are logged and skipped.
+func (c *ResultConsumer) handleMessage(ctx context.Context, m kafka.Message) (err error) {
+	ctx = correlation.FromKafka(ctx, m.Headers)
+	ctx, span := tracing.StartProcess(ctx, m, c.reader.Config().GroupID)
+	defer func() { tracing.End(span, err) }()
+
+	result, err := decodeResult(m)
+	if errors.Is(err, models.ErrUnsupportedEvent) {
+		// Newer producers may emit versions this build does not understand; skip them explicitly
+		slog.WarnContext(ctx, "Rejecting unsupported event", "error", err)
+		metrics.ConsumerError(m, c.reader.Config().GroupID, metrics.ReasonUnsupported)
+		return nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to unmarshal delivery result", "error", err, "data", string(m.Value))
+		metrics.ConsumerError(m, c.reader.Config().GroupID, metrics.ReasonDecode)
+		return nil
+	}
+
+	processCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	err = c.service.RecordDelivery(processCtx, result)
+	cancel()
+
+	var statusErr service.UnknownDeliveryStatusError
+	switch {
+	case errors.Is(err, storage.ErrNotFound), errors.As(err, &statusErr):
+		slog.ErrorContext(ctx, "Dropping delivery result", "reminder_id", result.ReminderID, "error", err)
+		return nil
+	case err != nil:
+		// ErrStatusChanged lands here too: the retry re-reads the reminder and
+		// applies the receipt to its new status.
+		return err
+	}
+
+	slog.DebugContext(ctx, "Recorded delivery result", "reminder_id", result.ReminderID, "status", result.Status, "channel", result.Channel)
+	return nil
+}
+
+// decodeResult reads a v1 notification.result envelope in whatever encoding
+// the message header names, or a bare JSON NotificationResult written before
+// envelopes were introduced.
+func decodeResult(m kafka.Message) (models.NotificationResult, error) {
+	codec, err := events.CodecFor(m.Headers)
+	if err != nil {
+		return models.NotificationResult{}, err
+	}
+
+	envelope, err := codec.Decode(m.Value)
+	if errors.Is(err, models.ErrNoEnvelope) {
+		var result models.NotificationResult
+		err = json.Unmarshal(m.Value, &result)
+		return result, err
+	}
+	if err != nil {
+		return models.NotificationResult{}, err
+	}
+
+	return models.NotificationResultFromEnvelope(envelope)
+}
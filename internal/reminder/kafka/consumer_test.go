@@ -0,0 +1,238 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/internal/reminder/service"
+	"github.com/kiribu/jwt-practice/internal/reminder/storage"
+	"github.com/kiribu/jwt-practice/models"
+	"github.com/kiribu/jwt-practice/pkg/events"
+	"github.com/segmentio/kafka-go"
+)
+
+// queueReader hands out queued messages and records commits.
+type queueReader struct {
+	mu        sync.Mutex
+	messages  []kafka.Message
+	committed []int64
+}
+
+func (r *queueReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	r.mu.Lock()
+	if len(r.messages) > 0 {
+		m := r.messages[0]
+		r.messages = r.messages[1:]
+		r.mu.Unlock()
+		return m, nil
+	}
+	r.mu.Unlock()
+
+	<-ctx.Done()
+	return kafka.Message{}, ctx.Err()
+}
+
+func (r *queueReader) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, m := range msgs {
+		r.committed = append(r.committed, m.Offset)
+	}
+	return nil
+}
+
+func (r *queueReader) Config() kafka.ReaderConfig {
+	return kafka.ReaderConfig{Topic: "reminder_results", GroupID: "test"}
+}
+
+func (r *queueReader) Close() error { return nil }
+
+func (r *queueReader) commits() []int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int64(nil), r.committed...)
+}
+
+// flakyStorage fails RecordDeliveryAttempt with failErr for the first
+// failures calls, then stores the attempt.
+type flakyStorage struct {
+	storage.ReminderStorage
+	reminder models.Reminder
+	failErr  error
+	failures int
+
+	mu       sync.Mutex
+	calls    int
+	recorded []models.DeliveryAttempt
+}
+
+func (s *flakyStorage) GetReminder(ctx context.Context, id uuid.UUID) (*models.Reminder, error) {
+	if id != s.reminder.ID {
+		return nil, storage.ErrNotFound
+	}
+	r := s.reminder
+	return &r, nil
+}
+
+func (s *flakyStorage) RecordDeliveryAttempt(ctx context.Context, attempt models.DeliveryAttempt, from, to models.ReminderStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	if s.calls <= s.failures {
+		return s.failErr
+	}
+	s.recorded = append(s.recorded, attempt)
+	return nil
+}
+
+func TestResultConsumerRetriesTransientErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"storage error", errors.New("connection reset by peer")},
+		{"status changed", storage.ErrStatusChanged},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reminder := models.Reminder{ID: uuid.New(), UserID: uuid.New(), Status: models.ReminderStatusQueued}
+			store := &flakyStorage{reminder: reminder, failErr: tt.err, failures: 2}
+
+			receipt, _ := json.Marshal(models.NotificationResult{
+				ResultID:   uuid.New(),
+				ReminderID: reminder.ID,
+				Status:     models.DeliveryStatusDelivered,
+				Channel:    "log",
+				Timestamp:  time.Now(),
+			})
+			reader := &queueReader{messages: []kafka.Message{{Offset: 7, Value: receipt}}}
+
+			consumer := &ResultConsumer{
+				reader:    reader,
+				service:   service.NewReminderService(store, nil),
+				retryBase: time.Millisecond,
+				retryMax:  time.Millisecond,
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				consumer.Start(ctx)
+				close(done)
+			}()
+
+			deadline := time.Now().Add(5 * time.Second)
+			for len(reader.commits()) == 0 && time.Now().Before(deadline) {
+				time.Sleep(time.Millisecond)
+			}
+			cancel()
+			<-done
+
+			if got := reader.commits(); len(got) != 1 || got[0] != 7 {
+				t.Fatalf("committed offsets = %v, want [7]", got)
+			}
+			if store.calls != 3 {
+				t.Errorf("RecordDeliveryAttempt calls = %d, want 3", store.calls)
+			}
+			if len(store.recorded) != 1 || store.recorded[0].ReminderID != reminder.ID {
+				t.Errorf("recorded = %v, want the receipt for %s", store.recorded, reminder.ID)
+			}
+		})
+	}
+}
+
+func TestResultConsumerDoesNotCommitOnShutdown(t *testing.T) {
+	reminder := models.Reminder{ID: uuid.New(), UserID: uuid.New(), Status: models.ReminderStatusQueued}
+	store := &flakyStorage{reminder: reminder, failErr: errors.New("database is down"), failures: 1 << 30}
+
+	receipt, _ := json.Marshal(models.NotificationResult{
+		ResultID:   uuid.New(),
+		ReminderID: reminder.ID,
+		Status:     models.DeliveryStatusDelivered,
+		Timestamp:  time.Now(),
+	})
+	reader := &queueReader{messages: []kafka.Message{{Offset: 3, Value: receipt}}}
+
+	consumer := &ResultConsumer{
+		reader:    reader,
+		service:   service.NewReminderService(store, nil),
+		retryBase: time.Millisecond,
+		retryMax:  time.Millisecond,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	consumer.Start(ctx)
+
+	if got := reader.commits(); len(got) != 0 {
+		t.Fatalf("committed offsets = %v, want none while the receipt is unrecorded", got)
+	}
+}
+
+func TestResultConsumerReadsEnvelopes(t *testing.T) {
+	reminder := models.Reminder{ID: uuid.New(), UserID: uuid.New(), Status: models.ReminderStatusQueued}
+	result := models.NotificationResult{
+		ResultID:   uuid.New(),
+		ReminderID: reminder.ID,
+		Status:     models.DeliveryStatusDelivered,
+		Channel:    "log",
+		Timestamp:  time.Now(),
+	}
+	jsonHeader := events.Header(events.JSONCodec{})
+
+	envelope := func(t *testing.T, change func(*models.Envelope)) []byte {
+		t.Helper()
+		env, err := models.NewNotificationResultEnvelope(result)
+		if err != nil {
+			t.Fatalf("NewNotificationResultEnvelope: %v", err)
+		}
+		change(&env)
+		data, err := json.Marshal(env)
+		if err != nil {
+			t.Fatalf("marshal envelope: %v", err)
+		}
+		return data
+	}
+	bare, _ := json.Marshal(result)
+
+	tests := []struct {
+		name       string
+		message    kafka.Message
+		wantRecord bool
+	}{
+		{"v1 envelope", kafka.Message{Value: envelope(t, func(*models.Envelope) {}), Headers: []kafka.Header{jsonHeader}}, true},
+		{"bare receipt from before envelopes", kafka.Message{Value: bare}, true},
+		{"unknown data version", kafka.Message{Value: envelope(t, func(e *models.Envelope) {
+			e.Type = "com.kiribu.reminders.notification.result.v2"
+		}), Headers: []kafka.Header{jsonHeader}}, false},
+		{"unknown spec version", kafka.Message{Value: envelope(t, func(e *models.Envelope) { e.SpecVersion = "2.0" }), Headers: []kafka.Header{jsonHeader}}, false},
+		{"unknown encoding", kafka.Message{Value: bare, Headers: []kafka.Header{{Key: events.ContentTypeHeader, Value: []byte("application/avro")}}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &flakyStorage{reminder: reminder}
+			consumer := &ResultConsumer{
+				reader:  &queueReader{},
+				service: service.NewReminderService(store, nil),
+			}
+
+			// Unsupported versions are skipped, not retried
+			if err := consumer.handleMessage(context.Background(), tt.message); err != nil {
+				t.Fatalf("handleMessage: %v", err)
+			}
+			if got := len(store.recorded) == 1; got != tt.wantRecord {
+				t.Fatalf("recorded = %v, want recorded %v", store.recorded, tt.wantRecord)
+			}
+			if tt.wantRecord && store.recorded[0].ID != result.ResultID {
+				t.Errorf("recorded attempt %s, want the receipt %s", store.recorded[0].ID, result.ResultID)
+			}
+		})
+	}
+}
@@ -2,58 +2,127 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
 
 	"github.com/kiribu/jwt-practice/models"
+	"github.com/kiribu/jwt-practice/pkg/correlation"
+	"github.com/kiribu/jwt-practice/pkg/events"
+	"github.com/kiribu/jwt-practice/pkg/tracing"
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// Event is one message of a batch passed to SendEvents.
+type Event struct {
+	Key           string
+	Envelope      models.Envelope
+	CorrelationID string // Written as a header so consumers can log it
+	TraceParent   string // Parent of the publish span; empty to use the caller's span
+}
+
 type Producer struct {
 	writer *kafka.Writer
+	codec  events.Codec
 }
 
-func NewProducer(brokers []string, topic string) *Producer {
+func NewProducer(brokers []string, topic string, codec events.Codec) *Producer {
 	writer := &kafka.Writer{
 		Addr:                   kafka.TCP(brokers...),
 		Topic:                  topic,
-		Balancer:               &kafka.LeastBytes{},
+		Balancer:               &kafka.Hash{}, // Same key, same partition: per-key ordering
 		AllowAutoTopicCreation: true,
+		// Writes are synchronous, so waiting to fill a batch only adds latency
+		BatchTimeout: 10 * time.Millisecond,
+		BatchSize:    500,
 	}
 
-	return &Producer{writer: writer}
+	return &Producer{writer: writer, codec: codec}
 }
 
 func (p *Producer) Close() error {
 	return p.writer.Close()
 }
 
-func (p *Producer) SendNotification(reminder models.Reminder) error {
-	return p.SendEvent(fmt.Sprintf("%d", reminder.ID), reminder)
-}
-
-func (p *Producer) SendEvent(key string, payload interface{}) error {
-	data, err := json.Marshal(payload)
+func (p *Producer) message(event Event, now time.Time) (kafka.Message, error) {
+	data, err := p.codec.Encode(event.Envelope)
 	if err != nil {
-		return fmt.Errorf("failed to marshal payload: %w", err)
+		return kafka.Message{}, fmt.Errorf("failed to encode event: %w", err)
 	}
 
-	msg := kafka.Message{
-		Key:   []byte(key),
-		Value: data,
-		Time:  time.Now(),
+	headers := []kafka.Header{events.Header(p.codec)}
+	if event.CorrelationID != "" {
+		headers = append(headers, kafka.Header{Key: correlation.KafkaHeader, Value: []byte(event.CorrelationID)})
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	return kafka.Message{
+		Key:     []byte(event.Key),
+		Value:   data,
+		Headers: headers,
+		Time:    now,
+	}, nil
+}
 
-	err = p.writer.WriteMessages(ctx, msg)
-	if err != nil {
-		return fmt.Errorf("failed to write message to kafka: %w", err)
+// SendEvents publishes all events in one write, in the topic's encoding and
+// with their correlation IDs as headers. Messages with equal keys go to one
+// partition and are consumed in the order they were sent. It returns nil if
+// every event was written, otherwise a slice with one entry per event, nil for
+// the ones that succeeded. Each message gets its own publish span, linked to
+// the span in ctx.
+func (p *Producer) SendEvents(ctx context.Context, batch []Event) []error {
+	errs := make([]error, len(batch))
+	msgs := make([]kafka.Message, 0, len(batch))
+	spans := make([]trace.Span, 0, len(batch))
+	index := make([]int, 0, len(batch)) // msgs[i] is batch[index[i]]
+
+	link := trace.LinkFromContext(ctx)
+	now := time.Now()
+	failed := false
+	for i, event := range batch {
+		msg, err := p.message(event, now)
+		if err != nil {
+			errs[i] = err
+			failed = true
+			continue
+		}
+		_, span := tracing.StartPublish(tracing.WithTraceParent(ctx, event.TraceParent), &msg, p.writer.Topic, link)
+		msgs = append(msgs, msg)
+		spans = append(spans, span)
+		index = append(index, i)
 	}
 
-	slog.Debug("Sent message to Kafka", "topic", p.writer.Topic, "key", key)
-	return nil
+	if len(msgs) > 0 {
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+
+		err := p.writer.WriteMessages(ctx, msgs...)
+		var writeErrs kafka.WriteErrors
+		switch {
+		case err == nil:
+		case errors.As(err, &writeErrs):
+			for i, werr := range writeErrs {
+				if werr != nil {
+					errs[index[i]] = fmt.Errorf("failed to write message to kafka: %w", werr)
+					failed = true
+				}
+			}
+		default:
+			for _, i := range index {
+				errs[i] = fmt.Errorf("failed to write message to kafka: %w", err)
+			}
+			failed = true
+		}
+	}
+
+	for i, span := range spans {
+		tracing.End(span, errs[index[i]])
+	}
+
+	slog.Debug("Sent batch to Kafka", "topic", p.writer.Topic, "count", len(msgs), "failed", failed)
+	if !failed {
+		return nil
+	}
+	return errs
 }
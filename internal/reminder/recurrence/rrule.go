@@ -0,0 +1,80 @@
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/teambition/rrule-go"
+)
+
+const DefaultTimezone = "UTC"
+
+// Normalize validates an RFC 5545 RRULE and timezone and returns them in the
+// canonical form stored on a reminder. An empty rule means a one-shot reminder.
+func Normalize(rule, timezone string) (string, string, error) {
+	rule = strings.TrimSpace(rule)
+	timezone = strings.TrimSpace(timezone)
+
+	if rule == "" {
+		if timezone != "" {
+			if _, err := time.LoadLocation(timezone); err != nil {
+				return "", "", fmt.Errorf("invalid timezone: %s", timezone)
+			}
+		}
+		return "", timezone, nil
+	}
+
+	if timezone == "" {
+		timezone = DefaultTimezone
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid timezone: %s", timezone)
+	}
+
+	rule = strings.TrimPrefix(strings.ToUpper(rule), "RRULE:")
+	opt, err := rrule.StrToROptionInLocation(rule, loc)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid rrule: %w", err)
+	}
+	if !opt.Dtstart.IsZero() {
+		return "", "", errors.New("invalid rrule: DTSTART is taken from remind_at and must not be set")
+	}
+	if _, err := rrule.NewRRule(*opt); err != nil {
+		return "", "", fmt.Errorf("invalid rrule: %w", err)
+	}
+
+	return rule, timezone, nil
+}
+
+// Next returns the first occurrence of the rule strictly after the given time.
+// The rule is anchored at dtstart, evaluated in the given timezone.
+// ok is false when the recurrence is exhausted (COUNT or UNTIL reached).
+func Next(rule, timezone string, dtstart, after time.Time) (next time.Time, ok bool, err error) {
+	if timezone == "" {
+		timezone = DefaultTimezone
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid timezone: %s", timezone)
+	}
+
+	opt, err := rrule.StrToROptionInLocation(rule, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid rrule: %w", err)
+	}
+	opt.Dtstart = dtstart.In(loc)
+
+	r, err := rrule.NewRRule(*opt)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid rrule: %w", err)
+	}
+
+	next = r.After(after, false)
+	if next.IsZero() {
+		return time.Time{}, false, nil
+	}
+	return next, true, nil
+}
@@ -0,0 +1,120 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     string
+		timezone string
+		wantRule string
+		wantTZ   string
+	}{
+		{"one-shot keeps its timezone", "", "Europe/Berlin", "", "Europe/Berlin"},
+		{"one-shot without timezone", "  ", "", "", ""},
+		{"upper-cased with default timezone", "freq=daily", "", "FREQ=DAILY", "UTC"},
+		{"RRULE prefix dropped", "RRULE:FREQ=WEEKLY;BYDAY=MO,WE", "America/New_York", "FREQ=WEEKLY;BYDAY=MO,WE", "America/New_York"},
+		{"last business day", "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", "UTC", "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", "UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, timezone, err := Normalize(tt.rule, tt.timezone)
+			if err != nil {
+				t.Fatalf("Normalize: %v", err)
+			}
+			if rule != tt.wantRule || timezone != tt.wantTZ {
+				t.Errorf("Normalize = %q, %q, want %q, %q", rule, timezone, tt.wantRule, tt.wantTZ)
+			}
+		})
+	}
+}
+
+func TestNormalizeRejectsInvalidRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     string
+		timezone string
+	}{
+		{"unknown frequency", "FREQ=SOMETIMES", "UTC"},
+		{"missing frequency", "BYDAY=MO", "UTC"},
+		{"malformed count", "FREQ=DAILY;COUNT=many", "UTC"},
+		{"unknown property", "FREQ=DAILY;EVERY=2", "UTC"},
+		{"DTSTART set", "FREQ=DAILY;DTSTART=20260101T090000Z", "UTC"},
+		{"unknown timezone", "FREQ=DAILY", "Mars/Olympus_Mons"},
+		{"one-shot with unknown timezone", "", "Mars/Olympus_Mons"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rule, timezone, err := Normalize(tt.rule, tt.timezone); err == nil {
+				t.Errorf("Normalize = %q, %q, want an error", rule, timezone)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	utc := func(s string) time.Time {
+		t.Helper()
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatalf("parse %s: %v", s, err)
+		}
+		return v
+	}
+
+	tests := []struct {
+		name     string
+		rule     string
+		timezone string
+		dtstart  string
+		after    string
+		want     string // Empty when the recurrence is exhausted
+	}{
+		{"daily", "FREQ=DAILY", "UTC", "2026-03-02T09:00:00Z", "2026-03-02T09:00:00Z", "2026-03-03T09:00:00Z"},
+		{"daily after a missed day", "FREQ=DAILY", "UTC", "2026-03-02T09:00:00Z", "2026-03-05T12:00:00Z", "2026-03-06T09:00:00Z"},
+		{"weekly to the next weekday", "FREQ=WEEKLY;BYDAY=MO,WE", "UTC", "2026-03-02T09:00:00Z", "2026-03-02T09:00:00Z", "2026-03-04T09:00:00Z"},
+		{"weekly into the next week", "FREQ=WEEKLY;BYDAY=MO,WE", "UTC", "2026-03-02T09:00:00Z", "2026-03-04T09:00:00Z", "2026-03-09T09:00:00Z"},
+
+		// February 28 is a Saturday, March 31 a Tuesday
+		{"last business day before a weekend", "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", "UTC", "2026-01-30T17:00:00Z", "2026-01-30T17:00:00Z", "2026-02-27T17:00:00Z"},
+		{"last business day on the last day", "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", "UTC", "2026-01-30T17:00:00Z", "2026-02-27T17:00:00Z", "2026-03-31T17:00:00Z"},
+
+		// COUNT and UNTIL are counted from the recurrence start, not from the
+		// occurrence that fired last
+		{"count remaining", "FREQ=DAILY;COUNT=3", "UTC", "2026-03-02T09:00:00Z", "2026-03-03T09:00:00Z", "2026-03-04T09:00:00Z"},
+		{"count exhausted", "FREQ=DAILY;COUNT=3", "UTC", "2026-03-02T09:00:00Z", "2026-03-04T09:00:00Z", ""},
+		{"until is inclusive", "FREQ=DAILY;UNTIL=20260305T090000Z", "UTC", "2026-03-02T09:00:00Z", "2026-03-04T09:00:00Z", "2026-03-05T09:00:00Z"},
+		{"until exhausted", "FREQ=DAILY;UNTIL=20260305T090000Z", "UTC", "2026-03-02T09:00:00Z", "2026-03-05T09:00:00Z", ""},
+
+		// Occurrences keep their wall-clock time across DST changes
+		{"spring forward", "FREQ=DAILY", "Europe/Berlin", "2026-03-27T08:00:00Z", "2026-03-28T08:00:00Z", "2026-03-29T07:00:00Z"},
+		{"fall back", "FREQ=DAILY", "America/New_York", "2026-10-30T13:00:00Z", "2026-10-31T13:00:00Z", "2026-11-01T14:00:00Z"},
+		{"weekly across DST", "FREQ=WEEKLY", "Europe/Berlin", "2026-03-23T08:00:00Z", "2026-03-23T08:00:00Z", "2026-03-30T07:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, ok, err := Next(tt.rule, tt.timezone, utc(tt.dtstart), utc(tt.after))
+			if err != nil {
+				t.Fatalf("Next: %v", err)
+			}
+			if tt.want == "" {
+				if ok {
+					t.Errorf("Next = %s, want the recurrence exhausted", next)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("Next exhausted, want %s", tt.want)
+			}
+			if want := utc(tt.want); !next.Equal(want) {
+				t.Errorf("Next = %s, want %s", next.UTC(), want)
+			}
+		})
+	}
+}
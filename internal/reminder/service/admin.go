@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/internal/reminder/storage"
+	"github.com/kiribu/jwt-practice/models"
+)
+
+const (
+	defaultAdminPageSize = 50
+	maxAdminPageSize     = 500
+	maxResolveBatch      = 1000
+)
+
+// OutboxAdminService lets operators inspect and resolve dead-lettered outbox events.
+type OutboxAdminService struct {
+	storage storage.ReminderStorage
+}
+
+func NewOutboxAdminService(storage storage.ReminderStorage) *OutboxAdminService {
+	return &OutboxAdminService{storage: storage}
+}
+
+func (s *OutboxAdminService) ListFailed(ctx context.Context, limit, offset int) ([]models.OutboxEvent, error) {
+	limit, offset = pageBounds(limit, offset)
+	return s.storage.GetFailedOutboxEvents(ctx, limit, offset)
+}
+
+func (s *OutboxAdminService) GetEvent(ctx context.Context, id uuid.UUID) (*models.OutboxEvent, error) {
+	return s.storage.GetOutboxEvent(ctx, id)
+}
+
+// Requeue returns FAILED events to PENDING with a fresh retry budget.
+func (s *OutboxAdminService) Requeue(ctx context.Context, ids []uuid.UUID, actor string) ([]uuid.UUID, error) {
+	if err := validateResolve(ids, actor); err != nil {
+		return nil, err
+	}
+
+	requeued, err := s.storage.RequeueOutboxEvents(ctx, ids, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("Outbox events requeued", "actor", actor, "requested", len(ids), "requeued", requeued)
+	return requeued, nil
+}
+
+// Discard marks FAILED events DISCARDED so they are never sent.
+func (s *OutboxAdminService) Discard(ctx context.Context, ids []uuid.UUID, actor string) ([]uuid.UUID, error) {
+	if err := validateResolve(ids, actor); err != nil {
+		return nil, err
+	}
+
+	discarded, err := s.storage.DiscardOutboxEvents(ctx, ids, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("Outbox events discarded", "actor", actor, "requested", len(ids), "discarded", discarded)
+	return discarded, nil
+}
+
+func (s *OutboxAdminService) AuditLog(ctx context.Context, limit, offset int) ([]models.OutboxAuditEntry, error) {
+	limit, offset = pageBounds(limit, offset)
+	return s.storage.GetOutboxAuditLog(ctx, limit, offset)
+}
+
+func validateResolve(ids []uuid.UUID, actor string) error {
+	if actor == "" {
+		return errors.New("actor is required")
+	}
+	if len(ids) == 0 {
+		return errors.New("at least one event id is required")
+	}
+	if len(ids) > maxResolveBatch {
+		return errors.New("too many event ids in one request")
+	}
+	return nil
+}
+
+func pageBounds(limit, offset int) (int, int) {
+	if limit <= 0 {
+		limit = defaultAdminPageSize
+	}
+	if limit > maxAdminPageSize {
+		limit = maxAdminPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
@@ -6,21 +6,30 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/internal/reminder/recurrence"
 	"github.com/kiribu/jwt-practice/internal/reminder/storage"
 	"github.com/kiribu/jwt-practice/models"
 )
 
+// Scheduler is told about every remind_at change so reminders fire on time.
+type Scheduler interface {
+	Schedule(id uuid.UUID, remindAt time.Time)
+	Unschedule(id uuid.UUID)
+}
+
 type ReminderService struct {
-	storage storage.ReminderStorage
+	storage   storage.ReminderStorage
+	scheduler Scheduler
 }
 
-func NewReminderService(storage storage.ReminderStorage) *ReminderService {
+func NewReminderService(storage storage.ReminderStorage, scheduler Scheduler) *ReminderService {
 	return &ReminderService{
-		storage: storage,
+		storage:   storage,
+		scheduler: scheduler,
 	}
 }
 
-func (s *ReminderService) Create(ctx context.Context, userID uuid.UUID, title, description, remindAtStr string) (*models.Reminder, error) {
+func (s *ReminderService) Create(ctx context.Context, userID uuid.UUID, title, description, remindAtStr, rrule, timezone string) (*models.Reminder, error) {
 	if title == "" {
 		return nil, errors.New("title is required")
 	}
@@ -34,23 +43,33 @@ func (s *ReminderService) Create(ctx context.Context, userID uuid.UUID, title, d
 		return nil, errors.New("remind_at must be in the future")
 	}
 
-	reminder, err := s.storage.Create(ctx, userID, title, description, remindAt)
+	rrule, timezone, err = recurrence.Normalize(rrule, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	reminder, err := s.storage.Create(ctx, userID, title, description, remindAt, rrule, timezone)
 	if err != nil {
 		return nil, err
 	}
 
+	s.scheduler.Schedule(reminder.ID, reminder.RemindAt)
 	return reminder, nil
 }
 
 func (s *ReminderService) GetByUserID(ctx context.Context, userID uuid.UUID, status string) ([]models.Reminder, error) {
-	return s.storage.GetByUserID(ctx, userID, status)
+	statuses, err := ParseStatusFilter(status)
+	if err != nil {
+		return nil, err
+	}
+	return s.storage.GetByUserID(ctx, userID, statuses)
 }
 
 func (s *ReminderService) GetByID(ctx context.Context, userID, id uuid.UUID) (*models.Reminder, error) {
 	return s.storage.GetByID(ctx, userID, id)
 }
 
-func (s *ReminderService) Update(ctx context.Context, userID, id uuid.UUID, title, description, remindAtStr string) (*models.Reminder, error) {
+func (s *ReminderService) Update(ctx context.Context, userID, id uuid.UUID, title, description, remindAtStr, rrule, timezone string) (*models.Reminder, error) {
 	if title == "" {
 		return nil, errors.New("title is required")
 	}
@@ -64,14 +83,181 @@ func (s *ReminderService) Update(ctx context.Context, userID, id uuid.UUID, titl
 		return nil, errors.New("remind_at must be in the future")
 	}
 
-	reminder, err := s.storage.Update(ctx, userID, id, title, description, remindAt)
+	rrule, timezone, err = recurrence.Normalize(rrule, timezone)
 	if err != nil {
 		return nil, err
 	}
 
+	current, err := s.loadForTransition(ctx, userID, id, models.ReminderStatusScheduled)
+	if err != nil {
+		return nil, err
+	}
+
+	reminder, err := s.storage.Update(ctx, userID, id, current.Status, title, description, remindAt, rrule, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	s.scheduler.Schedule(reminder.ID, reminder.RemindAt)
 	return reminder, nil
 }
 
 func (s *ReminderService) Delete(ctx context.Context, userID, id uuid.UUID) error {
-	return s.storage.Delete(ctx, userID, id)
+	current, err := s.loadForTransition(ctx, userID, id, models.ReminderStatusCancelled)
+	if err != nil {
+		return err
+	}
+	if err := s.storage.Cancel(ctx, userID, id, current.Status); err != nil {
+		return err
+	}
+
+	s.scheduler.Unschedule(id)
+	return nil
+}
+
+func (s *ReminderService) Snooze(ctx context.Context, userID, id uuid.UUID, durationStr, remindAtStr string) (*models.Reminder, error) {
+	var remindAt time.Time
+
+	switch {
+	case durationStr != "" && remindAtStr != "":
+		return nil, InputError("specify either duration or remind_at, not both")
+	case durationStr != "":
+		duration, err := time.ParseDuration(durationStr)
+		if err != nil {
+			return nil, InputError("invalid duration format, use Go duration: 10m, 1h30m")
+		}
+		if duration <= 0 {
+			return nil, InputError("duration must be positive")
+		}
+		remindAt = time.Now().Add(duration)
+	case remindAtStr != "":
+		var err error
+		remindAt, err = time.Parse(time.RFC3339, remindAtStr)
+		if err != nil {
+			return nil, InputError("invalid remind_at format, use RFC3339: 2026-01-25T10:00:00+03:00")
+		}
+		if remindAt.Before(time.Now()) {
+			return nil, InputError("remind_at must be in the future")
+		}
+	default:
+		return nil, InputError("duration or remind_at is required")
+	}
+
+	current, err := s.storage.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case firedOccurrence(current):
+		// The snoozed occurrence must fire before the series' next one, or it would be skipped
+		next, ok := nextAfterFired(current)
+		if ok && !remindAt.Before(next) {
+			return nil, InputError("remind_at must be before the next occurrence at " + next.Format(time.RFC3339))
+		}
+	case current.Status == models.ReminderStatusScheduled:
+		// scheduled -> scheduled is an edit, not a snooze
+		return nil, TransitionError("reminder has not fired yet")
+	default:
+		if err := ValidateTransition(current.Status, models.ReminderStatusScheduled); err != nil {
+			return nil, err
+		}
+	}
+
+	reminder, err := s.storage.Snooze(ctx, userID, id, current.Status, remindAt)
+	if err != nil {
+		return nil, err
+	}
+
+	s.scheduler.Schedule(reminder.ID, reminder.RemindAt)
+	return reminder, nil
+}
+
+func (s *ReminderService) Acknowledge(ctx context.Context, userID, id uuid.UUID) (*models.Reminder, error) {
+	current, err := s.storage.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Acknowledging an occurrence leaves the series scheduled for the next one
+	if firedOccurrence(current) {
+		if current.AcknowledgedAt != nil {
+			return nil, TransitionError("occurrence is already acknowledged")
+		}
+		return s.storage.Acknowledge(ctx, userID, id, current.Status, current.Status)
+	}
+
+	if err := ValidateTransition(current.Status, models.ReminderStatusAcknowledged); err != nil {
+		return nil, err
+	}
+	return s.storage.Acknowledge(ctx, userID, id, current.Status, models.ReminderStatusAcknowledged)
+}
+
+// RecordDelivery applies a delivery receipt from notification-service.
+// The attempt is always stored; the status only moves when the transition is valid,
+// e.g. recurring reminders stay scheduled and acknowledged ones stay acknowledged.
+func (s *ReminderService) RecordDelivery(ctx context.Context, result models.NotificationResult) error {
+	var to models.ReminderStatus
+	switch result.Status {
+	case models.DeliveryStatusDelivered:
+		to = models.ReminderStatusDelivered
+	case models.DeliveryStatusFailed:
+		to = models.ReminderStatusFailed
+	default:
+		return UnknownDeliveryStatusError(result.Status)
+	}
+
+	reminder, err := s.storage.GetReminder(ctx, result.ReminderID)
+	if err != nil {
+		return err
+	}
+
+	from := reminder.Status
+	if !CanTransition(from, to) {
+		to = from
+	}
+
+	attempt := models.DeliveryAttempt{
+		ID:          result.ResultID,
+		ReminderID:  result.ReminderID,
+		UserID:      reminder.UserID,
+		Status:      result.Status,
+		Channel:     result.Channel,
+		Reason:      result.Reason,
+		AttemptedAt: result.Timestamp,
+	}
+
+	return s.storage.RecordDeliveryAttempt(ctx, attempt, from, to)
+}
+
+func (s *ReminderService) GetDeliveryHistory(ctx context.Context, userID, id uuid.UUID) ([]models.DeliveryAttempt, error) {
+	if _, err := s.storage.GetByID(ctx, userID, id); err != nil {
+		return nil, err
+	}
+	return s.storage.GetDeliveryAttempts(ctx, id)
+}
+
+// loadForTransition fetches the reminder and checks that it may move to the given status.
+func (s *ReminderService) loadForTransition(ctx context.Context, userID, id uuid.UUID, to models.ReminderStatus) (*models.Reminder, error) {
+	reminder, err := s.storage.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateTransition(reminder.Status, to); err != nil {
+		return nil, err
+	}
+	return reminder, nil
+}
+
+// nextAfterFired returns the series occurrence that follows the one that fired
+// last, ignoring any snooze in between. ok is false once the rule is exhausted.
+func nextAfterFired(r *models.Reminder) (time.Time, bool) {
+	dtstart := r.RemindAt
+	if r.RecurrenceStart != nil {
+		dtstart = *r.RecurrenceStart
+	}
+	next, ok, err := recurrence.Next(r.RRule, r.Timezone, dtstart, *r.LastFiredAt)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return next, ok
 }
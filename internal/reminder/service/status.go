@@ -0,0 +1,118 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kiribu/jwt-practice/models"
+)
+
+// transitions lists every allowed reminder status change.
+// scheduled -> scheduled covers edits of a reminder that has not fired yet.
+var transitions = map[models.ReminderStatus][]models.ReminderStatus{
+	models.ReminderStatusScheduled: {
+		models.ReminderStatusScheduled,
+		models.ReminderStatusQueued,
+		models.ReminderStatusCancelled,
+	},
+	models.ReminderStatusQueued: {
+		models.ReminderStatusDelivered,
+		models.ReminderStatusFailed,
+		models.ReminderStatusAcknowledged,
+		models.ReminderStatusScheduled, // snooze
+	},
+	models.ReminderStatusDelivered: {
+		models.ReminderStatusAcknowledged,
+		models.ReminderStatusScheduled, // snooze
+	},
+	models.ReminderStatusFailed: {
+		models.ReminderStatusDelivered, // late receipt after a retry
+		models.ReminderStatusScheduled, // snooze
+	},
+	models.ReminderStatusAcknowledged: {
+		models.ReminderStatusScheduled, // snooze
+	},
+	models.ReminderStatusCancelled: {},
+}
+
+// TransitionError is returned when the reminder's current status does not
+// allow the requested action.
+type TransitionError string
+
+func (e TransitionError) Error() string { return string(e) }
+
+// InputError is returned for a request that can never succeed as sent.
+type InputError string
+
+func (e InputError) Error() string { return string(e) }
+
+// UnknownDeliveryStatusError is returned for a delivery receipt whose status
+// this build does not know.
+type UnknownDeliveryStatusError string
+
+func (e UnknownDeliveryStatusError) Error() string { return "unknown delivery status: " + string(e) }
+
+// firedOccurrence reports whether r is a recurring reminder whose latest
+// occurrence has fired. The series stays scheduled, so snooze and acknowledge
+// act on that occurrence instead of going through the status machine.
+func firedOccurrence(r *models.Reminder) bool {
+	return r.Status == models.ReminderStatusScheduled && r.RRule != "" && r.LastFiredAt != nil
+}
+
+// activeStatuses is used for the default listing, which hides cancelled reminders.
+var activeStatuses = []models.ReminderStatus{
+	models.ReminderStatusScheduled,
+	models.ReminderStatusQueued,
+	models.ReminderStatusDelivered,
+	models.ReminderStatusFailed,
+	models.ReminderStatusAcknowledged,
+}
+
+// legacyStatusFilters keeps the filters from the is_sent era working.
+var legacyStatusFilters = map[string][]models.ReminderStatus{
+	"pending": {models.ReminderStatusScheduled},
+	"sent": {
+		models.ReminderStatusQueued,
+		models.ReminderStatusDelivered,
+		models.ReminderStatusFailed,
+		models.ReminderStatusAcknowledged,
+	},
+}
+
+func CanTransition(from, to models.ReminderStatus) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func ValidateTransition(from, to models.ReminderStatus) error {
+	if !CanTransition(from, to) {
+		return TransitionError(fmt.Sprintf("reminder cannot move from %q to %q", from, to))
+	}
+	return nil
+}
+
+// ParseStatusFilter turns the status query of GetReminders into a set of states.
+// It accepts a single state, a comma-separated list, or the legacy "pending"/"sent".
+func ParseStatusFilter(filter string) ([]models.ReminderStatus, error) {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		return activeStatuses, nil
+	}
+	if statuses, ok := legacyStatusFilters[filter]; ok {
+		return statuses, nil
+	}
+
+	var statuses []models.ReminderStatus
+	for _, part := range strings.Split(filter, ",") {
+		status := models.ReminderStatus(strings.TrimSpace(part))
+		if _, ok := transitions[status]; !ok {
+			return nil, fmt.Errorf("unknown status: %s", part)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
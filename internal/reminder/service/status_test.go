@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/internal/reminder/storage"
+	"github.com/kiribu/jwt-practice/models"
+)
+
+// memoryStorage keeps a single reminder in memory. Methods the tests do not
+// need panic through the embedded nil interface.
+type memoryStorage struct {
+	storage.ReminderStorage
+	reminder models.Reminder
+}
+
+func (m *memoryStorage) GetByID(ctx context.Context, userID, id uuid.UUID) (*models.Reminder, error) {
+	if m.reminder.UserID != userID || m.reminder.ID != id {
+		return nil, storage.ErrNotFound
+	}
+	r := m.reminder
+	return &r, nil
+}
+
+func (m *memoryStorage) Snooze(ctx context.Context, userID, id uuid.UUID, from models.ReminderStatus, remindAt time.Time) (*models.Reminder, error) {
+	if m.reminder.Status != from {
+		return nil, storage.ErrStatusChanged
+	}
+	m.reminder.RemindAt = remindAt
+	m.reminder.Status = models.ReminderStatusScheduled
+	m.reminder.AcknowledgedAt = nil
+	r := m.reminder
+	return &r, nil
+}
+
+func (m *memoryStorage) Acknowledge(ctx context.Context, userID, id uuid.UUID, from, to models.ReminderStatus) (*models.Reminder, error) {
+	if m.reminder.Status != from {
+		return nil, storage.ErrStatusChanged
+	}
+	now := time.Now()
+	m.reminder.Status = to
+	m.reminder.AcknowledgedAt = &now
+	r := m.reminder
+	return &r, nil
+}
+
+type noopScheduler struct{}
+
+func (noopScheduler) Schedule(uuid.UUID, time.Time) {}
+func (noopScheduler) Unschedule(uuid.UUID)          {}
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to models.ReminderStatus
+		want     bool
+	}{
+		{models.ReminderStatusScheduled, models.ReminderStatusQueued, true},
+		{models.ReminderStatusScheduled, models.ReminderStatusAcknowledged, false},
+		{models.ReminderStatusQueued, models.ReminderStatusDelivered, true},
+		{models.ReminderStatusDelivered, models.ReminderStatusAcknowledged, true},
+		{models.ReminderStatusDelivered, models.ReminderStatusScheduled, true},
+		{models.ReminderStatusAcknowledged, models.ReminderStatusAcknowledged, false},
+		{models.ReminderStatusCancelled, models.ReminderStatusScheduled, false},
+	}
+
+	for _, tt := range tests {
+		if got := CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+// recurringReminder returns a daily reminder whose occurrence an hour ago has
+// fired, with the series already moved on to tomorrow.
+func recurringReminder(fired bool) models.Reminder {
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	r := models.Reminder{
+		ID:              uuid.New(),
+		UserID:          uuid.New(),
+		Title:           "standup",
+		RemindAt:        start,
+		Status:          models.ReminderStatusScheduled,
+		RRule:           "FREQ=DAILY",
+		Timezone:        "UTC",
+		RecurrenceStart: &start,
+	}
+	if fired {
+		r.RemindAt = start.AddDate(0, 0, 1)
+		r.LastFiredAt = &start
+	}
+	return r
+}
+
+func TestSnoozeRecurringOccurrence(t *testing.T) {
+	store := &memoryStorage{reminder: recurringReminder(true)}
+	svc := NewReminderService(store, noopScheduler{})
+	r := store.reminder
+
+	snoozed, err := svc.Snooze(context.Background(), r.UserID, r.ID, "10m", "")
+	if err != nil {
+		t.Fatalf("Snooze: %v", err)
+	}
+	if snoozed.Status != models.ReminderStatusScheduled {
+		t.Errorf("status = %s, want scheduled", snoozed.Status)
+	}
+	if !snoozed.RemindAt.Before(time.Now().Add(11 * time.Minute)) {
+		t.Errorf("remind_at = %s, want about 10 minutes from now", snoozed.RemindAt)
+	}
+}
+
+func TestSnoozeRecurringPastNextOccurrence(t *testing.T) {
+	store := &memoryStorage{reminder: recurringReminder(true)}
+	svc := NewReminderService(store, noopScheduler{})
+	r := store.reminder
+
+	if _, err := svc.Snooze(context.Background(), r.UserID, r.ID, "48h", ""); err == nil {
+		t.Fatal("Snooze past the next occurrence succeeded, want error")
+	}
+}
+
+func TestSnoozeRecurringBeforeFirstOccurrence(t *testing.T) {
+	store := &memoryStorage{reminder: recurringReminder(false)}
+	svc := NewReminderService(store, noopScheduler{})
+	r := store.reminder
+
+	if _, err := svc.Snooze(context.Background(), r.UserID, r.ID, "10m", ""); err == nil {
+		t.Fatal("Snooze before the first occurrence succeeded, want error")
+	}
+}
+
+func TestAcknowledgeRecurringOccurrence(t *testing.T) {
+	store := &memoryStorage{reminder: recurringReminder(true)}
+	svc := NewReminderService(store, noopScheduler{})
+	r := store.reminder
+
+	acked, err := svc.Acknowledge(context.Background(), r.UserID, r.ID)
+	if err != nil {
+		t.Fatalf("Acknowledge: %v", err)
+	}
+	if acked.Status != models.ReminderStatusScheduled {
+		t.Errorf("status = %s, want the series to stay scheduled", acked.Status)
+	}
+	if acked.AcknowledgedAt == nil {
+		t.Error("acknowledged_at is not set")
+	}
+
+	if _, err := svc.Acknowledge(context.Background(), r.UserID, r.ID); err == nil {
+		t.Error("second Acknowledge of the same occurrence succeeded, want error")
+	}
+}
+
+func TestAcknowledgeOneShot(t *testing.T) {
+	r := models.Reminder{ID: uuid.New(), UserID: uuid.New(), Status: models.ReminderStatusDelivered}
+	store := &memoryStorage{reminder: r}
+	svc := NewReminderService(store, noopScheduler{})
+
+	acked, err := svc.Acknowledge(context.Background(), r.UserID, r.ID)
+	if err != nil {
+		t.Fatalf("Acknowledge: %v", err)
+	}
+	if acked.Status != models.ReminderStatusAcknowledged {
+		t.Errorf("status = %s, want acknowledged", acked.Status)
+	}
+
+	store.reminder.Status = models.ReminderStatusScheduled
+	if _, err := svc.Acknowledge(context.Background(), r.UserID, r.ID); err == nil {
+		t.Error("Acknowledge of a one-shot reminder that has not fired succeeded, want error")
+	}
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// OutboxNotifyChannel is the Postgres NOTIFY channel raised by every
+// transaction that writes to reminders_outbox.
+const OutboxNotifyChannel = "reminders_outbox"
+
+// Listener turns Postgres NOTIFY messages into wake-ups. It keeps a dedicated
+// connection outside the GORM pool and reconnects when it drops.
+type Listener struct {
+	dsn     string
+	channel string
+	wake    chan struct{}
+}
+
+func NewListener(dsn, channel string) *Listener {
+	return &Listener{
+		dsn:     dsn,
+		channel: channel,
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// C receives a value after one or more notifications. Bursts are coalesced.
+func (l *Listener) C() <-chan struct{} {
+	return l.wake
+}
+
+func (l *Listener) Start(ctx context.Context) {
+	backoff := time.Second
+
+	for {
+		err := l.listen(ctx)
+		if ctx.Err() != nil {
+			slog.Info("Stopping Postgres listener...", "channel", l.channel)
+			return
+		}
+
+		slog.Error("Postgres listener disconnected, reconnecting", "channel", l.channel, "backoff", backoff, "error", err)
+		// Notifications sent while disconnected are lost, so let the consumer catch up
+		l.signal()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, 30*time.Second)
+	}
+}
+
+func (l *Listener) listen(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, l.dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{l.channel}.Sanitize()); err != nil {
+		return err
+	}
+	slog.Info("Listening for Postgres notifications", "channel", l.channel)
+
+	for {
+		if _, err := conn.WaitForNotification(ctx); err != nil {
+			return err
+		}
+		l.signal()
+	}
+}
+
+func (l *Listener) signal() {
+	select {
+	case l.wake <- struct{}{}:
+	default:
+	}
+}
@@ -5,29 +5,65 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/kiribu/jwt-practice/models"
+	"github.com/kiribu/jwt-practice/pkg/correlation"
+	"github.com/kiribu/jwt-practice/pkg/tracing"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 type ReminderStorage interface {
-	Create(ctx context.Context, userID uuid.UUID, title, description string, remindAt time.Time) (*models.Reminder, error)
-	GetByUserID(ctx context.Context, userID uuid.UUID, status string) ([]models.Reminder, error)
+	Create(ctx context.Context, userID uuid.UUID, title, description string, remindAt time.Time, rrule, timezone string) (*models.Reminder, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID, statuses []models.ReminderStatus) ([]models.Reminder, error)
 	GetByID(ctx context.Context, userID, id uuid.UUID) (*models.Reminder, error)
-	Update(ctx context.Context, userID, id uuid.UUID, title, description string, remindAt time.Time) (*models.Reminder, error)
-	Delete(ctx context.Context, userID, id uuid.UUID) error
-	GetPending(ctx context.Context) ([]models.Reminder, error)
-	MarkAsSent(ctx context.Context, id uuid.UUID) error
+	// Status-changing methods only apply when the reminder is still in the
+	// "from" status the caller validated the transition against.
+	Update(ctx context.Context, userID, id uuid.UUID, from models.ReminderStatus, title, description string, remindAt time.Time, rrule, timezone string) (*models.Reminder, error)
+	Cancel(ctx context.Context, userID, id uuid.UUID, from models.ReminderStatus) error
+	Snooze(ctx context.Context, userID, id uuid.UUID, from models.ReminderStatus, remindAt time.Time) (*models.Reminder, error)
+	Acknowledge(ctx context.Context, userID, id uuid.UUID, from, to models.ReminderStatus) (*models.Reminder, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, from, to models.ReminderStatus) error
+	ClaimPending(ctx context.Context, limit int, next NextOccurrenceFunc) ([]models.Reminder, error)
+	GetUpcoming(ctx context.Context, until time.Time, limit int) ([]models.Reminder, error)
+	// Delivery receipts
+	GetReminder(ctx context.Context, id uuid.UUID) (*models.Reminder, error)
+	RecordDeliveryAttempt(ctx context.Context, attempt models.DeliveryAttempt, from, to models.ReminderStatus) error
+	GetDeliveryAttempts(ctx context.Context, reminderID uuid.UUID) ([]models.DeliveryAttempt, error)
 	// Outbox methods
-	GetPendingOutboxEvents(ctx context.Context, limit int) ([]models.OutboxEvent, error)
-	MarkOutboxEventAsSent(ctx context.Context, id uuid.UUID) error
-	IncrementOutboxRetryCount(ctx context.Context, id uuid.UUID, errMsg string) error
-	CreateNotificationEventsAndMarkSent(ctx context.Context, reminder models.Reminder) error
+	ClaimPendingOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEvent, error)
+	ReleaseOutboxEvents(ctx context.Context, ids []uuid.UUID) error
+	MarkOutboxEventsAsSent(ctx context.Context, ids []uuid.UUID) error
+	// IncrementOutboxRetryCount records a failed attempt and postpones the event
+	// by retryAfter, or marks it FAILED once maxAttempts is reached.
+	IncrementOutboxRetryCount(ctx context.Context, id uuid.UUID, errMsg string, retryAfter time.Duration, maxAttempts int) error
+	// Dead-letter administration
+	GetFailedOutboxEvents(ctx context.Context, limit, offset int) ([]models.OutboxEvent, error)
+	GetOutboxEvent(ctx context.Context, id uuid.UUID) (*models.OutboxEvent, error)
+	RequeueOutboxEvents(ctx context.Context, ids []uuid.UUID, actor string) ([]uuid.UUID, error)
+	DiscardOutboxEvents(ctx context.Context, ids []uuid.UUID, actor string) ([]uuid.UUID, error)
+	GetOutboxAuditLog(ctx context.Context, limit, offset int) ([]models.OutboxAuditEntry, error)
+	// Retention
+	DeleteSentOutboxEvents(ctx context.Context, before time.Time, limit int) (int64, error)
+	CountSentOutboxEvents(ctx context.Context, before time.Time) (int64, error)
+	CountOutboxEventsByStatus(ctx context.Context) (map[string]int64, error)
 }
 
+// NextOccurrenceFunc returns when a fired reminder should fire again, or false
+// to retire it.
+type NextOccurrenceFunc func(reminder models.Reminder) (time.Time, bool)
+
+// ErrNotFound is returned when the reminder does not exist or belongs to another user.
+var ErrNotFound = errors.New("reminder not found")
+
+// ErrStatusChanged is returned when a reminder is missing or no longer in the
+// status a transition was validated against.
+var ErrStatusChanged = errors.New("reminder not found or its status has changed")
+
 type PostgresStorage struct {
 	db *gorm.DB
 }
@@ -43,39 +79,49 @@ func (s *PostgresStorage) createOutboxEvent(tx *gorm.DB, eventType string, userI
 	}
 
 	outboxEvent := models.OutboxEvent{
-		ID:          uuid.Must(uuid.NewV7()),
-		EventType:   eventType,
-		AggregateID: aggregateID,
-		UserID:      userID,
-		Payload:     payloadJSON,
+		ID:            uuid.Must(uuid.NewV7()),
+		EventType:     eventType,
+		AggregateID:   aggregateID,
+		UserID:        userID,
+		Payload:       payloadJSON,
+		CorrelationID: correlation.FromContext(tx.Statement.Context),
+		TraceParent:   tracing.TraceParent(tx.Statement.Context),
 	}
 
-	return tx.Create(&outboxEvent).Error
+	if err := tx.Create(&outboxEvent).Error; err != nil {
+		return err
+	}
+
+	// Delivered on commit; duplicates within one transaction are folded by Postgres
+	return tx.Exec("SELECT pg_notify(?, '')", OutboxNotifyChannel).Error
 }
 
-func (s *PostgresStorage) Create(ctx context.Context, userID uuid.UUID, title, description string, remindAt time.Time) (*models.Reminder, error) {
+func (s *PostgresStorage) Create(ctx context.Context, userID uuid.UUID, title, description string, remindAt time.Time, rrule, timezone string) (*models.Reminder, error) {
 	var reminder models.Reminder
 
 	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		reminder = models.Reminder{
-			ID:          uuid.Must(uuid.NewV7()),
-			UserID:      userID,
-			Title:       title,
-			Description: description,
-			RemindAt:    remindAt,
+			ID:            uuid.Must(uuid.NewV7()),
+			UserID:        userID,
+			Title:         title,
+			Description:   description,
+			RemindAt:      remindAt,
+			Status:        models.ReminderStatusScheduled,
+			RRule:         rrule,
+			Timezone:      timezone,
+			CorrelationID: correlation.FromContext(ctx),
+		}
+		if rrule != "" {
+			reminder.RecurrenceStart = &remindAt
 		}
 
 		if err := tx.Create(&reminder).Error; err != nil {
 			return fmt.Errorf("failed to insert reminder: %w", err)
 		}
 
-		event := models.LifecycleEvent{
-			EventID:    uuid.Must(uuid.NewV7()),
-			EventType:  "created",
-			ReminderID: reminder.ID,
-			UserID:     reminder.UserID,
-			Timestamp:  time.Now(),
-			Payload:    reminder,
+		event, err := models.NewLifecycleEnvelope("created", reminder.ID, reminder.UserID, &reminder)
+		if err != nil {
+			return err
 		}
 
 		if err := s.createOutboxEvent(tx, "created", reminder.UserID, reminder.ID, event); err != nil {
@@ -91,17 +137,15 @@ func (s *PostgresStorage) Create(ctx context.Context, userID uuid.UUID, title, d
 	return &reminder, nil
 }
 
-func (s *PostgresStorage) GetByUserID(ctx context.Context, userID uuid.UUID, status string) ([]models.Reminder, error) {
+func (s *PostgresStorage) GetByUserID(ctx context.Context, userID uuid.UUID, statuses []models.ReminderStatus) ([]models.Reminder, error) {
 	var reminders []models.Reminder
-	query := s.db.WithContext(ctx).Where("user_id = ?", userID)
-
-	switch status {
-	case "pending":
-		query = query.Where("is_sent = ?", false).Order("remind_at ASC")
-	case "sent":
-		query = query.Where("is_sent = ?", true).Order("remind_at DESC")
-	default:
+	query := s.db.WithContext(ctx).Where("user_id = ? AND status IN ?", userID, statuses)
+
+	// Upcoming reminders first; history (nothing scheduled) newest first
+	if slices.Contains(statuses, models.ReminderStatusScheduled) {
 		query = query.Order("remind_at ASC")
+	} else {
+		query = query.Order("remind_at DESC")
 	}
 
 	if err := query.Find(&reminders).Error; err != nil {
@@ -115,35 +159,42 @@ func (s *PostgresStorage) GetByID(ctx context.Context, userID, id uuid.UUID) (*m
 	var reminder models.Reminder
 	result := s.db.WithContext(ctx).Where("user_id = ? AND id = ?", userID, id).First(&reminder)
 	if result.Error != nil {
-		return nil, errors.New("reminder not found")
+		return nil, ErrNotFound
 	}
 	return &reminder, nil
 }
 
-func (s *PostgresStorage) Update(ctx context.Context, userID, id uuid.UUID, title, description string, remindAt time.Time) (*models.Reminder, error) {
+func (s *PostgresStorage) Update(ctx context.Context, userID, id uuid.UUID, from models.ReminderStatus, title, description string, remindAt time.Time, rrule, timezone string) (*models.Reminder, error) {
 	var reminder models.Reminder
 
 	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		result := tx.Where("user_id = ? AND id = ? AND is_sent = ?", userID, id, false).First(&reminder)
+		result := tx.Where("user_id = ? AND id = ? AND status = ?", userID, id, from).First(&reminder)
 		if result.Error != nil {
-			return errors.New("reminder not found or already sent")
+			return ErrStatusChanged
 		}
 
 		reminder.Title = title
 		reminder.Description = description
 		reminder.RemindAt = remindAt
+		reminder.Status = models.ReminderStatusScheduled
+		reminder.RRule = rrule
+		reminder.Timezone = timezone
+		reminder.RecurrenceStart = nil
+		reminder.LastFiredAt = nil
+		if rrule != "" {
+			reminder.RecurrenceStart = &remindAt
+		}
+		if id := correlation.FromContext(ctx); id != "" {
+			reminder.CorrelationID = id
+		}
 
 		if err := tx.Save(&reminder).Error; err != nil {
 			return fmt.Errorf("failed to update reminder: %w", err)
 		}
 
-		event := models.LifecycleEvent{
-			EventID:    uuid.Must(uuid.NewV7()),
-			EventType:  "updated",
-			ReminderID: reminder.ID,
-			UserID:     reminder.UserID,
-			Timestamp:  time.Now(),
-			Payload:    reminder,
+		event, err := models.NewLifecycleEnvelope("updated", reminder.ID, reminder.UserID, &reminder)
+		if err != nil {
+			return err
 		}
 
 		if err := s.createOutboxEvent(tx, "updated", reminder.UserID, reminder.ID, event); err != nil {
@@ -159,24 +210,24 @@ func (s *PostgresStorage) Update(ctx context.Context, userID, id uuid.UUID, titl
 	return &reminder, nil
 }
 
-func (s *PostgresStorage) Delete(ctx context.Context, userID, id uuid.UUID) error {
+// Cancel keeps the row for history and moves it to the cancelled status.
+// Analytics still receives the "deleted" lifecycle event.
+func (s *PostgresStorage) Cancel(ctx context.Context, userID, id uuid.UUID, from models.ReminderStatus) error {
 	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		result := tx.Where("user_id = ? AND id = ? AND is_sent = ?", userID, id, false).Delete(&models.Reminder{})
+		result := tx.Model(&models.Reminder{}).
+			Where("user_id = ? AND id = ? AND status = ?", userID, id, from).
+			Update("status", models.ReminderStatusCancelled)
 		if result.Error != nil {
 			return result.Error
 		}
 
 		if result.RowsAffected == 0 {
-			return errors.New("reminder not found or already sent")
+			return ErrStatusChanged
 		}
 
-		event := models.LifecycleEvent{
-			EventID:    uuid.Must(uuid.NewV7()),
-			EventType:  "deleted",
-			ReminderID: id,
-			UserID:     userID,
-			Timestamp:  time.Now(),
-			Payload:    nil,
+		event, err := models.NewLifecycleEnvelope("deleted", id, userID, nil)
+		if err != nil {
+			return err
 		}
 
 		if err := s.createOutboxEvent(tx, "deleted", userID, id, event); err != nil {
@@ -187,100 +238,528 @@ func (s *PostgresStorage) Delete(ctx context.Context, userID, id uuid.UUID) erro
 	})
 }
 
-func (s *PostgresStorage) GetPending(ctx context.Context) ([]models.Reminder, error) {
-	var reminders []models.Reminder
-	err := s.db.WithContext(ctx).Where("is_sent = ? AND remind_at <= ?", false, time.Now()).Find(&reminders).Error
+// Snooze re-arms a delivered reminder, or the fired occurrence of a recurring
+// one, so that it fires again at remindAt.
+func (s *PostgresStorage) Snooze(ctx context.Context, userID, id uuid.UUID, from models.ReminderStatus, remindAt time.Time) (*models.Reminder, error) {
+	var reminder models.Reminder
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("user_id = ? AND id = ? AND status = ?", userID, id, from).First(&reminder)
+		if result.Error != nil {
+			return ErrStatusChanged
+		}
+
+		reminder.RemindAt = remindAt
+		reminder.Status = models.ReminderStatusScheduled
+		reminder.AcknowledgedAt = nil
+		if id := correlation.FromContext(ctx); id != "" {
+			reminder.CorrelationID = id
+		}
+
+		if err := tx.Save(&reminder).Error; err != nil {
+			return fmt.Errorf("failed to snooze reminder: %w", err)
+		}
+
+		event, err := models.NewLifecycleEnvelope("snoozed", reminder.ID, reminder.UserID, &reminder)
+		if err != nil {
+			return err
+		}
+
+		if err := s.createOutboxEvent(tx, "snoozed", reminder.UserID, reminder.ID, event); err != nil {
+			return fmt.Errorf("failed to create outbox event: %w", err)
+		}
+
+		return nil
+	})
+
 	if err != nil {
 		return nil, err
 	}
-	return reminders, nil
+	return &reminder, nil
+}
+
+// Acknowledge records that the user has seen a delivered reminder and moves it
+// to "to". Recurring reminders pass from == to and stay scheduled.
+func (s *PostgresStorage) Acknowledge(ctx context.Context, userID, id uuid.UUID, from, to models.ReminderStatus) (*models.Reminder, error) {
+	var reminder models.Reminder
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("user_id = ? AND id = ? AND status = ?", userID, id, from).First(&reminder)
+		if result.Error != nil {
+			return ErrStatusChanged
+		}
+
+		now := time.Now()
+		reminder.Status = to
+		reminder.AcknowledgedAt = &now
+
+		if err := tx.Save(&reminder).Error; err != nil {
+			return fmt.Errorf("failed to acknowledge reminder: %w", err)
+		}
+
+		event, err := models.NewLifecycleEnvelope("acknowledged", reminder.ID, reminder.UserID, &reminder)
+		if err != nil {
+			return err
+		}
+
+		if err := s.createOutboxEvent(tx, "acknowledged", reminder.UserID, reminder.ID, event); err != nil {
+			return fmt.Errorf("failed to create outbox event: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return &reminder, nil
 }
 
-func (s *PostgresStorage) MarkAsSent(ctx context.Context, id uuid.UUID) error {
-	return s.db.WithContext(ctx).Model(&models.Reminder{}).Where("id = ?", id).Update("is_sent", true).Error
+// ClaimPending fires up to limit due reminders in one transaction. Rows are
+// locked with FOR UPDATE SKIP LOCKED, so concurrent reminder-service instances
+// split the due reminders between them instead of firing them twice. Each
+// reminder is marked in its own savepoint so one bad row does not hold back
+// the batch. It returns the reminders that fired, with recurring ones already
+// moved to their next remind_at.
+func (s *PostgresStorage) ClaimPending(ctx context.Context, limit int, next NextOccurrenceFunc) ([]models.Reminder, error) {
+	var fired []models.Reminder
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var reminders []models.Reminder
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND remind_at <= ?", models.ReminderStatusScheduled, time.Now()).
+			Order("remind_at ASC").
+			Limit(limit).
+			Find(&reminders).Error
+		if err != nil {
+			return err
+		}
+
+		for _, reminder := range reminders {
+			// Events of the firing are traced back to the request that scheduled it
+			rctx := correlation.WithID(ctx, reminder.CorrelationID)
+			err := tx.WithContext(rctx).Transaction(func(tx *gorm.DB) error {
+				if at, ok := next(reminder); ok {
+					if err := s.createNotificationEventsAndReschedule(tx, reminder, at); err != nil {
+						return err
+					}
+					reminder.RemindAt = at
+					return nil
+				}
+
+				if err := s.createNotificationEventsAndMarkQueued(tx, reminder); err != nil {
+					return err
+				}
+				reminder.Status = models.ReminderStatusQueued
+				return nil
+			})
+			if err != nil {
+				slog.ErrorContext(rctx, "Error creating notification events", "reminder_id", reminder.ID, "error", err)
+				continue
+			}
+			fired = append(fired, reminder)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fired, nil
 }
 
-func (s *PostgresStorage) GetPendingOutboxEvents(ctx context.Context, limit int) ([]models.OutboxEvent, error) {
-	var events []models.OutboxEvent
-	err := s.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
-		Where("status = ? AND retry_count < ?", "PENDING", 5).
-		Order("created_at ASC").
+// GetUpcoming returns the IDs and remind_at of scheduled reminders due before
+// until, earliest first, for the in-memory scheduler.
+func (s *PostgresStorage) GetUpcoming(ctx context.Context, until time.Time, limit int) ([]models.Reminder, error) {
+	var reminders []models.Reminder
+	err := s.db.WithContext(ctx).
+		Select("id", "remind_at").
+		Where("status = ? AND remind_at <= ?", models.ReminderStatusScheduled, until).
+		Order("remind_at ASC").
 		Limit(limit).
-		Find(&events).Error
-	return events, err
+		Find(&reminders).Error
+	return reminders, err
+}
+
+func (s *PostgresStorage) UpdateStatus(ctx context.Context, id uuid.UUID, from, to models.ReminderStatus) error {
+	result := s.db.WithContext(ctx).Model(&models.Reminder{}).
+		Where("id = ? AND status = ?", id, from).
+		Update("status", to)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrStatusChanged
+	}
+	return nil
+}
+
+// GetReminder looks a reminder up without scoping it to a user, for internal consumers.
+func (s *PostgresStorage) GetReminder(ctx context.Context, id uuid.UUID) (*models.Reminder, error) {
+	var reminder models.Reminder
+	result := s.db.WithContext(ctx).Where("id = ?", id).First(&reminder)
+	if result.Error != nil {
+		return nil, ErrNotFound
+	}
+	return &reminder, nil
+}
+
+// RecordDeliveryAttempt stores a delivery receipt and moves the reminder from
+// "from" to "to" in one transaction. The status is left alone when from == to.
+// Receipts are idempotent on their ID, so redelivered Kafka messages are ignored.
+func (s *PostgresStorage) RecordDeliveryAttempt(ctx context.Context, attempt models.DeliveryAttempt, from, to models.ReminderStatus) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&attempt)
+		if result.Error != nil {
+			return fmt.Errorf("failed to insert delivery attempt: %w", result.Error)
+		}
+		if result.RowsAffected == 0 || from == to {
+			return nil
+		}
+
+		result = tx.Model(&models.Reminder{}).
+			Where("id = ? AND status = ?", attempt.ReminderID, from).
+			Update("status", to)
+		if result.Error != nil {
+			return fmt.Errorf("failed to update reminder status: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrStatusChanged
+		}
+
+		return nil
+	})
 }
 
-func (s *PostgresStorage) MarkOutboxEventAsSent(ctx context.Context, id uuid.UUID) error {
+func (s *PostgresStorage) GetDeliveryAttempts(ctx context.Context, reminderID uuid.UUID) ([]models.DeliveryAttempt, error) {
+	var attempts []models.DeliveryAttempt
+	err := s.db.WithContext(ctx).
+		Where("reminder_id = ?", reminderID).
+		Order("attempted_at ASC").
+		Find(&attempts).Error
+	return attempts, err
+}
+
+// outboxLockNamespace is the first key of the per-user advisory locks taken by
+// ClaimPendingOutboxEvents, so they cannot collide with locks taken elsewhere.
+const outboxLockNamespace = 0x6f7574 // "out"
+
+// ClaimPendingOutboxEvents leases due events oldest first so that a user's
+// events are only ever in flight on one worker. Each worker takes a
+// transaction-scoped advisory lock per user and skips users another worker
+// holds; their events are read only after the lock is taken, when an earlier
+// claim of the user has committed its lease. Claimed events get next_attempt_at
+// pushed past the lease, which holds back the user's later events until they
+// are marked SENT, retried or released. A worker that crashes mid-batch leaves
+// its events to be claimed again once the lease ends.
+func (s *PostgresStorage) ClaimPendingOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var users []uuid.UUID
+		err := tx.Raw(`
+			WITH candidates AS (
+				SELECT user_id, MIN(created_at) AS first_at
+				FROM reminders_outbox
+				WHERE status = 'PENDING' AND next_attempt_at <= NOW()
+				GROUP BY user_id
+				ORDER BY first_at
+				LIMIT ?
+			)
+			SELECT user_id FROM candidates
+			WHERE pg_try_advisory_xact_lock(?, hashtext(user_id::text))
+			ORDER BY first_at`, limit, outboxLockNamespace).
+			Scan(&users).Error
+		if err != nil || len(users) == 0 {
+			return err
+		}
+
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("status = ? AND next_attempt_at <= NOW() AND user_id IN ?", "PENDING", users).
+			// Hold back events queued behind a user's event that is leased or
+			// waiting for a retry
+			Where(`NOT EXISTS (
+				SELECT 1 FROM reminders_outbox earlier
+				WHERE earlier.user_id = reminders_outbox.user_id
+					AND earlier.status = 'PENDING'
+					AND earlier.next_attempt_at > NOW()
+					AND earlier.created_at < reminders_outbox.created_at
+			)`).
+			Order("created_at ASC, id ASC").
+			Limit(limit).
+			Find(&events).Error
+		if err != nil || len(events) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, len(events))
+		for i, event := range events {
+			ids[i] = event.ID
+		}
+		return tx.Model(&models.OutboxEvent{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", gorm.Expr("NOW() + ? * INTERVAL '1 millisecond'", lease.Milliseconds())).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// ReleaseOutboxEvents ends the lease of claimed events that were neither sent
+// nor retried, so they follow the user's earlier events without waiting for it
+// to run out.
+func (s *PostgresStorage) ReleaseOutboxEvents(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return s.db.WithContext(ctx).Model(&models.OutboxEvent{}).
+		Where("id IN ? AND status = ?", ids, "PENDING").
+		Update("next_attempt_at", gorm.Expr("NOW()")).Error
+}
+
+func (s *PostgresStorage) MarkOutboxEventsAsSent(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
 	now := time.Now()
 	return s.db.WithContext(ctx).Model(&models.OutboxEvent{}).
-		Where("id = ?", id).
+		Where("id IN ?", ids).
 		Updates(map[string]interface{}{
 			"status":       "SENT",
 			"processed_at": now,
 		}).Error
 }
 
-func (s *PostgresStorage) IncrementOutboxRetryCount(ctx context.Context, id uuid.UUID, errMsg string) error {
+// IncrementOutboxRetryCount schedules the retry on the database clock, the
+// same one ClaimPendingOutboxEvents compares against.
+func (s *PostgresStorage) IncrementOutboxRetryCount(ctx context.Context, id uuid.UUID, errMsg string, retryAfter time.Duration, maxAttempts int) error {
 	return s.db.WithContext(ctx).Model(&models.OutboxEvent{}).
 		Where("id = ?", id).
 		Updates(map[string]interface{}{
-			"retry_count":   gorm.Expr("retry_count + 1"),
-			"error_message": errMsg,
-			"status":        gorm.Expr("CASE WHEN retry_count + 1 >= 5 THEN 'FAILED' ELSE status END"),
+			"retry_count":     gorm.Expr("retry_count + 1"),
+			"error_message":   errMsg,
+			"next_attempt_at": gorm.Expr("NOW() + ? * INTERVAL '1 millisecond'", retryAfter.Milliseconds()),
+			"status":          gorm.Expr("CASE WHEN retry_count + 1 >= ? THEN 'FAILED' ELSE status END", maxAttempts),
 		}).Error
 }
 
-func (s *PostgresStorage) CreateNotificationEventsAndMarkSent(ctx context.Context, reminder models.Reminder) error {
-	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// notification_trigger: raw Reminder for notification-service
-		reminderJSON, err := json.Marshal(reminder)
-		if err != nil {
-			return fmt.Errorf("failed to marshal reminder: %w", err)
-		}
+func (s *PostgresStorage) GetFailedOutboxEvents(ctx context.Context, limit, offset int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	err := s.db.WithContext(ctx).
+		Where("status = ?", "FAILED").
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&events).Error
+	return events, err
+}
 
-		notificationEvent := models.OutboxEvent{
-			ID:          uuid.Must(uuid.NewV7()),
-			EventType:   "notification_trigger",
-			AggregateID: reminder.ID,
-			UserID:      reminder.UserID,
-			Payload:     reminderJSON,
-		}
+func (s *PostgresStorage) GetOutboxEvent(ctx context.Context, id uuid.UUID) (*models.OutboxEvent, error) {
+	var event models.OutboxEvent
+	result := s.db.WithContext(ctx).Where("id = ?", id).First(&event)
+	if result.Error != nil {
+		return nil, errors.New("outbox event not found")
+	}
+	return &event, nil
+}
 
-		if err := tx.Create(&notificationEvent).Error; err != nil {
-			return fmt.Errorf("failed to create notification_trigger event: %w", err)
-		}
+// RequeueOutboxEvents moves FAILED events back to PENDING with a fresh retry
+// budget and returns the IDs that were actually requeued.
+func (s *PostgresStorage) RequeueOutboxEvents(ctx context.Context, ids []uuid.UUID, actor string) ([]uuid.UUID, error) {
+	return s.resolveFailedOutboxEvents(ctx, ids, actor, models.OutboxAuditActionRequeue, map[string]interface{}{
+		"status":          "PENDING",
+		"retry_count":     0,
+		"next_attempt_at": gorm.Expr("NOW()"),
+	})
+}
 
-		// notification_sent: LifecycleEvent for analytics-service
-		lifecycleEvent := models.LifecycleEvent{
-			EventID:    uuid.Must(uuid.NewV7()),
-			EventType:  "notification_sent",
-			ReminderID: reminder.ID,
-			UserID:     reminder.UserID,
-			Timestamp:  time.Now(),
-			Payload:    reminder,
-		}
-		lifecycleEventJSON, err := json.Marshal(lifecycleEvent)
-		if err != nil {
-			return fmt.Errorf("failed to marshal lifecycle event: %w", err)
-		}
+// DiscardOutboxEvents marks FAILED events DISCARDED so they are never sent.
+func (s *PostgresStorage) DiscardOutboxEvents(ctx context.Context, ids []uuid.UUID, actor string) ([]uuid.UUID, error) {
+	return s.resolveFailedOutboxEvents(ctx, ids, actor, models.OutboxAuditActionDiscard, map[string]interface{}{
+		"status":       "DISCARDED",
+		"processed_at": time.Now(),
+	})
+}
 
-		lifecycleOutboxEvent := models.OutboxEvent{
-			ID:          uuid.Must(uuid.NewV7()),
-			EventType:   "notification_sent",
-			AggregateID: reminder.ID,
-			UserID:      reminder.UserID,
-			Payload:     lifecycleEventJSON,
-		}
+// resolveFailedOutboxEvents applies updates to the FAILED events among ids and
+// writes one audit entry per affected event in the same transaction.
+func (s *PostgresStorage) resolveFailedOutboxEvents(ctx context.Context, ids []uuid.UUID, actor, action string, updates map[string]interface{}) ([]uuid.UUID, error) {
+	var affected []uuid.UUID
 
-		if err := tx.Create(&lifecycleOutboxEvent).Error; err != nil {
-			return fmt.Errorf("failed to create notification_sent event: %w", err)
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var events []models.OutboxEvent
+		result := tx.Model(&events).
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+			Where("id IN ? AND status = ?", ids, "FAILED").
+			Updates(updates)
+		if result.Error != nil {
+			return fmt.Errorf("failed to %s outbox events: %w", action, result.Error)
 		}
 
-		// Mark reminder as sent
-		if err := tx.Model(&models.Reminder{}).Where("id = ?", reminder.ID).Update("is_sent", true).Error; err != nil {
-			return fmt.Errorf("failed to mark reminder as sent: %w", err)
+		for _, event := range events {
+			entry := models.OutboxAuditEntry{
+				ID:      uuid.Must(uuid.NewV7()),
+				Action:  action,
+				EventID: event.ID,
+				Actor:   actor,
+			}
+			if err := tx.Create(&entry).Error; err != nil {
+				return fmt.Errorf("failed to write audit entry: %w", err)
+			}
+			affected = append(affected, event.ID)
 		}
 
+		if action == models.OutboxAuditActionRequeue && len(affected) > 0 {
+			return tx.Exec("SELECT pg_notify(?, '')", OutboxNotifyChannel).Error
+		}
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	return affected, nil
+}
+
+func (s *PostgresStorage) GetOutboxAuditLog(ctx context.Context, limit, offset int) ([]models.OutboxAuditEntry, error) {
+	var entries []models.OutboxAuditEntry
+	err := s.db.WithContext(ctx).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&entries).Error
+	return entries, err
+}
+
+// DeleteSentOutboxEvents removes up to limit SENT events processed before the
+// cutoff, oldest first. It walks idx_outbox_cleanup.
+func (s *PostgresStorage) DeleteSentOutboxEvents(ctx context.Context, before time.Time, limit int) (int64, error) {
+	result := s.db.WithContext(ctx).Exec(`
+		DELETE FROM reminders_outbox
+		WHERE id IN (
+			SELECT id FROM reminders_outbox
+			WHERE status = 'SENT' AND processed_at < ?
+			ORDER BY processed_at
+			LIMIT ?
+		)`, before, limit)
+	return result.RowsAffected, result.Error
+}
+
+func (s *PostgresStorage) CountSentOutboxEvents(ctx context.Context, before time.Time) (int64, error) {
+	var count int64
+	err := s.db.WithContext(ctx).
+		Model(&models.OutboxEvent{}).
+		Where("status = ? AND processed_at < ?", "SENT", before).
+		Count(&count).Error
+	return count, err
+}
+
+func (s *PostgresStorage) CountOutboxEventsByStatus(ctx context.Context) (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	err := s.db.WithContext(ctx).
+		Model(&models.OutboxEvent{}).
+		Select("status, COUNT(*) AS count").
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+func (s *PostgresStorage) createNotificationEventsAndMarkQueued(tx *gorm.DB, reminder models.Reminder) error {
+	if err := s.createNotificationEvents(tx, reminder, "notification_sent"); err != nil {
+		return err
+	}
+
+	// Hand the reminder over to the outbox
+	result := tx.Model(&models.Reminder{}).
+		Where("id = ? AND status = ?", reminder.ID, models.ReminderStatusScheduled).
+		Updates(map[string]interface{}{
+			"status":        models.ReminderStatusQueued,
+			"last_fired_at": reminder.RemindAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to mark reminder as queued: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrStatusChanged
+	}
+
+	return nil
+}
+
+// createNotificationEventsAndReschedule fires the current occurrence of a
+// recurring reminder and moves remind_at to the next one instead of retiring it.
+// The fired occurrence is kept in last_fired_at and starts unacknowledged.
+func (s *PostgresStorage) createNotificationEventsAndReschedule(tx *gorm.DB, reminder models.Reminder, next time.Time) error {
+	if err := s.createNotificationEvents(tx, reminder, "occurrence_sent"); err != nil {
+		return err
+	}
+
+	result := tx.Model(&models.Reminder{}).
+		Where("id = ? AND status = ? AND remind_at = ?", reminder.ID, models.ReminderStatusScheduled, reminder.RemindAt).
+		Updates(map[string]interface{}{
+			"remind_at":       next,
+			"last_fired_at":   reminder.RemindAt,
+			"acknowledged_at": nil,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to reschedule reminder: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrStatusChanged
+	}
+
+	return nil
+}
+
+// createNotificationEvents writes the notification_trigger event for
+// notification-service and a lifecycle event of the given type for analytics-service.
+func (s *PostgresStorage) createNotificationEvents(tx *gorm.DB, reminder models.Reminder, lifecycleType string) error {
+	// notification_trigger: the reminder to deliver, for notification-service
+	trigger, err := models.NewEnvelope(models.EventTypeNotificationTrigger, reminder.ID, models.NotificationTriggerData{Reminder: reminder})
+	if err != nil {
+		return err
+	}
+	triggerJSON, err := json.Marshal(trigger)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification_trigger event: %w", err)
+	}
+
+	notificationEvent := models.OutboxEvent{
+		ID:            uuid.Must(uuid.NewV7()),
+		EventType:     "notification_trigger",
+		AggregateID:   reminder.ID,
+		UserID:        reminder.UserID,
+		Payload:       triggerJSON,
+		CorrelationID: correlation.FromContext(tx.Statement.Context),
+		TraceParent:   tracing.TraceParent(tx.Statement.Context),
+	}
+
+	if err := tx.Create(&notificationEvent).Error; err != nil {
+		return fmt.Errorf("failed to create notification_trigger event: %w", err)
+	}
+
+	// notification_sent / occurrence_sent: lifecycle event for analytics-service
+	lifecycleEvent, err := models.NewLifecycleEnvelope(lifecycleType, reminder.ID, reminder.UserID, &reminder)
+	if err != nil {
+		return err
+	}
+
+	if err := s.createOutboxEvent(tx, lifecycleType, reminder.UserID, reminder.ID, lifecycleEvent); err != nil {
+		return fmt.Errorf("failed to create %s event: %w", lifecycleType, err)
+	}
+
+	return nil
 }
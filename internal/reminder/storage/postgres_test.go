@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/internal/testdb"
+	"github.com/kiribu/jwt-practice/models"
+)
+
+// Every reminder-service instance runs the scheduler; each due reminder must
+// fire exactly once no matter how many of them claim at the same time.
+func TestClaimPendingConcurrently(t *testing.T) {
+	db := testdb.Open(t)
+	store := NewPostgresStorage(db)
+	ctx := context.Background()
+
+	user := models.User{ID: uuid.New(), Username: "claimer", PasswordHash: "x"}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	const reminders, schedulers = 150, 8
+	due := time.Now().Add(-time.Minute)
+	for i := range reminders {
+		rrule := ""
+		if i%3 == 0 {
+			rrule = "FREQ=DAILY"
+		}
+		if _, err := store.Create(ctx, user.ID, "r", "", due, rrule, "UTC"); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	// Recurring reminders move a day ahead, so they are not due again here
+	next := func(r models.Reminder) (time.Time, bool) {
+		if r.RRule == "" {
+			return time.Time{}, false
+		}
+		return r.RemindAt.Add(24 * time.Hour), true
+	}
+
+	var mu sync.Mutex
+	fired := make(map[uuid.UUID]int)
+	var wg sync.WaitGroup
+	for range schedulers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				batch, err := store.ClaimPending(ctx, 10, next)
+				if err != nil {
+					t.Errorf("ClaimPending: %v", err)
+					return
+				}
+				if len(batch) == 0 {
+					return
+				}
+				mu.Lock()
+				for _, r := range batch {
+					fired[r.ID]++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(fired) != reminders {
+		t.Errorf("fired %d distinct reminders, want %d", len(fired), reminders)
+	}
+	for id, n := range fired {
+		if n != 1 {
+			t.Errorf("reminder %s fired %d times", id, n)
+		}
+	}
+
+	var triggers []struct {
+		AggregateID uuid.UUID
+		Count       int
+	}
+	err := db.Model(&models.OutboxEvent{}).
+		Select("aggregate_id, COUNT(*) AS count").
+		Where("event_type = ?", "notification_trigger").
+		Group("aggregate_id").
+		Scan(&triggers).Error
+	if err != nil {
+		t.Fatalf("count triggers: %v", err)
+	}
+	if len(triggers) != reminders {
+		t.Errorf("%d reminders got a notification_trigger, want %d", len(triggers), reminders)
+	}
+	for _, tr := range triggers {
+		if tr.Count != 1 {
+			t.Errorf("reminder %s got %d notification_trigger events", tr.AggregateID, tr.Count)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// BackoffPolicy spaces out outbox retries so a broker outage does not burn
+// through every attempt within seconds.
+type BackoffPolicy struct {
+	Base        time.Duration // Delay after the first failure
+	Factor      float64       // Growth per further failure
+	Max         time.Duration // Upper bound for a single delay
+	Jitter      float64       // Random spread as a fraction of the delay, 0..1
+	MaxAttempts int           // Event is marked FAILED after this many failures
+}
+
+func DefaultBackoffPolicy() BackoffPolicy {
+	return BackoffPolicy{
+		Base:        time.Second,
+		Factor:      2,
+		Max:         5 * time.Minute,
+		Jitter:      0.2,
+		MaxAttempts: 10,
+	}
+}
+
+// Delay returns how long to wait after the given failed attempt (1-based).
+func (p BackoffPolicy) Delay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	delay := float64(p.Base) * math.Pow(p.Factor, float64(attempt-1))
+	if delay > float64(p.Max) || math.IsInf(delay, 0) {
+		delay = float64(p.Max)
+	}
+
+	if p.Jitter > 0 {
+		// Spread uniformly over [delay*(1-jitter), delay*(1+jitter)]
+		delay *= 1 + p.Jitter*(2*rand.Float64()-1)
+	}
+
+	return time.Duration(delay)
+}
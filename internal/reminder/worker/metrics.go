@@ -0,0 +1,125 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/kiribu/jwt-practice/internal/reminder/storage"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	schedulerLag = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "reminder_scheduler_lag_seconds",
+		Help:    "Delay between a reminder's remind_at and the moment it was fired.",
+		Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 15, 60, 300},
+	})
+
+	outboxPublishLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "reminder_outbox_publish_latency_seconds",
+		Help:    "Time from writing an outbox event to its successful publish to Kafka, retries included.",
+		Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 15, 60, 300},
+	}, []string{"event_type"})
+
+	outboxRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "reminder_outbox_retries_total",
+		Help: "Failed outbox publish attempts that were scheduled for a retry or dead-lettered.",
+	}, []string{"event_type"})
+
+	outboxFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "reminder_outbox_failed_total",
+		Help: "Outbox events that used up their attempts and were marked FAILED.",
+	}, []string{"event_type"})
+)
+
+// outboxStatuses are always reported, so an empty status shows as 0 rather than disappearing.
+var outboxStatuses = []string{"PENDING", "SENT", "FAILED", "DISCARDED"}
+
+// OutboxCollector reports the outbox backlog by status and the retention
+// worker's totals. Counting the backlog is a scan of reminders_outbox, so it
+// runs on its own timer in Start and scrapes are served the last counts.
+type OutboxCollector struct {
+	storage   storage.ReminderStorage
+	retention *RetentionWorker
+	interval  time.Duration
+
+	mu     sync.RWMutex
+	counts map[string]int64 // nil until the first successful count
+
+	backlog    *prometheus.Desc
+	purged     *prometheus.Desc
+	wouldPurge *prometheus.Desc
+}
+
+func NewOutboxCollector(storage storage.ReminderStorage, retention *RetentionWorker, interval time.Duration) *OutboxCollector {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	return &OutboxCollector{
+		storage:   storage,
+		retention: retention,
+		interval:  interval,
+		backlog:   prometheus.NewDesc("reminder_outbox_events", "Rows in reminders_outbox, by status, as of the last count.", []string{"status"}, nil),
+		purged:    prometheus.NewDesc("reminder_outbox_purged_total", "SENT events deleted by the retention worker.", nil, nil),
+		wouldPurge: prometheus.NewDesc("reminder_outbox_purgeable_events",
+			"SENT events old enough to delete, as counted by the last retention run in dry-run mode.", nil, nil),
+	}
+}
+
+// Start counts the backlog every interval until ctx is done.
+func (c *OutboxCollector) Start(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	c.refresh(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.refresh(ctx)
+		}
+	}
+}
+
+func (c *OutboxCollector) refresh(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	counts, err := c.storage.CountOutboxEventsByStatus(ctx)
+	if err != nil {
+		// Scrapes keep serving the previous counts
+		slog.Error("Failed to count outbox events", "error", err)
+		return
+	}
+
+	c.mu.Lock()
+	c.counts = counts
+	c.mu.Unlock()
+}
+
+func (c *OutboxCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.backlog
+	ch <- c.purged
+	ch <- c.wouldPurge
+}
+
+func (c *OutboxCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.retention.Stats()
+	ch <- prometheus.MustNewConstMetric(c.purged, prometheus.CounterValue, float64(stats.Purged))
+	ch <- prometheus.MustNewConstMetric(c.wouldPurge, prometheus.GaugeValue, float64(stats.LastWouldPurge))
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.counts == nil {
+		return
+	}
+	for _, status := range outboxStatuses {
+		ch <- prometheus.MustNewConstMetric(c.backlog, prometheus.GaugeValue, float64(c.counts[status]), status)
+	}
+}
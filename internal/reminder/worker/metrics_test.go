@@ -0,0 +1,61 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// countingStorage counts the outbox and how often it was asked to.
+type countingStorage struct {
+	retentionStorage
+	counts  map[string]int64
+	err     error
+	queries int
+}
+
+func (s *countingStorage) CountOutboxEventsByStatus(ctx context.Context) (map[string]int64, error) {
+	s.queries++
+	return s.counts, s.err
+}
+
+// Scrapes are served from the last count instead of querying Postgres.
+func TestOutboxCollectorServesCachedCounts(t *testing.T) {
+	store := &countingStorage{counts: map[string]int64{"PENDING": 3, "SENT": 7}}
+	c := NewOutboxCollector(store, NewRetentionWorker(store, RetentionConfig{}), 0)
+
+	if n := testutil.CollectAndCount(c, "reminder_outbox_events"); n != 0 {
+		t.Errorf("backlog reported %d series before the first count, want 0", n)
+	}
+
+	c.refresh(context.Background())
+	for range 3 {
+		testutil.CollectAndCount(c)
+	}
+	if store.queries != 1 {
+		t.Errorf("counted the outbox %d times, want once per refresh", store.queries)
+	}
+
+	want := `
+# HELP reminder_outbox_events Rows in reminders_outbox, by status, as of the last count.
+# TYPE reminder_outbox_events gauge
+reminder_outbox_events{status="DISCARDED"} 0
+reminder_outbox_events{status="FAILED"} 0
+reminder_outbox_events{status="PENDING"} 3
+reminder_outbox_events{status="SENT"} 7
+`
+	if err := testutil.CollectAndCompare(c, strings.NewReader(want), "reminder_outbox_events"); err != nil {
+		t.Error(err)
+	}
+
+	// A failed count keeps the previous one
+	store.err = errors.New("connection refused")
+	store.counts = nil
+	c.refresh(context.Background())
+	if err := testutil.CollectAndCompare(c, strings.NewReader(want), "reminder_outbox_events"); err != nil {
+		t.Errorf("after a failed refresh: %v", err)
+	}
+}
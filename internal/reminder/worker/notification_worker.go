@@ -3,56 +3,220 @@ package worker
 import (
 	"context"
 	"log/slog"
+	"sync"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/internal/reminder/recurrence"
 	"github.com/kiribu/jwt-practice/internal/reminder/storage"
+	"github.com/kiribu/jwt-practice/models"
+	"github.com/kiribu/jwt-practice/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// upcomingBatchSize bounds how many reminders one window load keeps in memory.
+const upcomingBatchSize = 1000
+
+// NotificationWorker fires reminders at their remind_at. Reminders due within
+// the lookahead window are loaded from Postgres into a min-heap and a single
+// timer is armed for the earliest one; the service re-arms it on every change,
+// so the database is only queried when something is due or the window slides.
 type NotificationWorker struct {
-	storage  storage.ReminderStorage
-	interval time.Duration
+	storage   storage.ReminderStorage
+	lookahead time.Duration
+	batchSize int // Reminders claimed per transaction
+
+	mu          sync.Mutex
+	queue       *scheduleQueue
+	loadedUntil time.Time // Every scheduled reminder due before this is in the queue
+	reloadAt    time.Time
+	wake        chan struct{}
 }
 
-func NewNotificationWorker(storage storage.ReminderStorage, interval time.Duration) *NotificationWorker {
+func NewNotificationWorker(storage storage.ReminderStorage, lookahead time.Duration, batchSize int) *NotificationWorker {
 	return &NotificationWorker{
-		storage:  storage,
-		interval: interval,
+		storage:   storage,
+		lookahead: lookahead,
+		batchSize: batchSize,
+		queue:     newScheduleQueue(),
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// Schedule arms the timer for a created, edited or snoozed reminder.
+func (w *NotificationWorker) Schedule(id uuid.UUID, remindAt time.Time) {
+	w.mu.Lock()
+	if !remindAt.After(w.loadedUntil) {
+		w.queue.set(id, remindAt)
+	} else {
+		// Beyond the window; the next load picks it up
+		w.queue.remove(id)
+	}
+	w.mu.Unlock()
+
+	w.notify()
+}
+
+// Unschedule drops a cancelled reminder.
+func (w *NotificationWorker) Unschedule(id uuid.UUID) {
+	w.mu.Lock()
+	w.queue.remove(id)
+	w.mu.Unlock()
+
+	w.notify()
+}
+
+func (w *NotificationWorker) notify() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
 	}
 }
 
 func (w *NotificationWorker) Start(ctx context.Context) {
-	ticker := time.NewTicker(w.interval)
-	defer ticker.Stop()
+	slog.Info("Reminder scheduler started", "lookahead", w.lookahead)
+
+	w.reload(ctx)
 
-	slog.Info("Reminder worker started", "interval", w.interval)
+	timer := time.NewTimer(w.nextWakeup(time.Now()))
+	defer timer.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
-			slog.Info("Stopping reminder worker...")
+			slog.Info("Stopping reminder scheduler...")
 			return
-		case <-ticker.C:
-			w.processPending(ctx)
+		case <-w.wake:
+		case <-timer.C:
+			now := time.Now()
+			if !now.Before(w.reloadDeadline()) {
+				w.reload(ctx)
+			}
+			if w.popDue(now) {
+				w.processPending(ctx)
+			}
 		}
+		timer.Reset(w.nextWakeup(time.Now()))
 	}
 }
 
-func (w *NotificationWorker) processPending(ctx context.Context) {
-	reminders, err := w.storage.GetPending(ctx)
+// reload loads the next window of scheduled reminders into the queue.
+func (w *NotificationWorker) reload(ctx context.Context) {
+	now := time.Now()
+	until := now.Add(w.lookahead)
+
+	reminders, err := w.storage.GetUpcoming(ctx, until, upcomingBatchSize)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// Slide the window at half its size so it never runs dry
+	w.reloadAt = now.Add(w.lookahead / 2)
+
 	if err != nil {
-		slog.Error("Error fetching pending reminders", "error", err)
+		slog.Error("Error loading upcoming reminders", "error", err)
 		return
 	}
 
-	if len(reminders) > 0 {
-		slog.Info("Found pending reminders, creating outbox events", "count", len(reminders))
+	for _, reminder := range reminders {
+		w.queue.set(reminder.ID, reminder.RemindAt)
 	}
 
-	for _, reminder := range reminders {
-		if err := w.storage.CreateNotificationEventsAndMarkSent(ctx, reminder); err != nil {
-			slog.Error("Error creating notification events", "reminder_id", reminder.ID, "error", err)
-		} else {
-			slog.Debug("Successfully created notification events", "reminder_id", reminder.ID)
+	w.loadedUntil = until
+	if len(reminders) == upcomingBatchSize {
+		// The window was truncated; only trust it up to the last loaded reminder
+		w.loadedUntil = reminders[len(reminders)-1].RemindAt
+		if w.loadedUntil.Before(w.reloadAt) {
+			w.reloadAt = w.loadedUntil
 		}
 	}
+
+	slog.Debug("Loaded upcoming reminders", "count", len(reminders), "until", w.loadedUntil)
+}
+
+func (w *NotificationWorker) reloadDeadline() time.Time {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.reloadAt
+}
+
+func (w *NotificationWorker) popDue(now time.Time) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.queue.popDue(now)
+}
+
+// nextWakeup returns how long to sleep until the earliest reminder or the next window load.
+func (w *NotificationWorker) nextWakeup(now time.Time) time.Duration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	at := w.reloadAt
+	if next, ok := w.queue.next(); ok && next.Before(at) {
+		at = next
+	}
+	if wait := at.Sub(now); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// processPending fires everything that is due, one claimed batch at a time.
+func (w *NotificationWorker) processPending(ctx context.Context) {
+	for {
+		// Trigger events written by the claim are published as children of this span
+		claimCtx, span := tracing.Tracer().Start(ctx, "scheduler.claim_pending")
+		fired, err := w.storage.ClaimPending(claimCtx, w.batchSize, fireReminder)
+		span.SetAttributes(attribute.Int("reminders.fired", len(fired)))
+		tracing.End(span, err)
+		if err != nil {
+			slog.Error("Error claiming pending reminders", "error", err)
+			return
+		}
+
+		if len(fired) > 0 {
+			slog.Info("Fired pending reminders", "count", len(fired))
+		}
+
+		for _, reminder := range fired {
+			if reminder.Status == models.ReminderStatusScheduled {
+				w.Schedule(reminder.ID, reminder.RemindAt)
+			}
+		}
+
+		if len(fired) < w.batchSize {
+			return
+		}
+	}
+}
+
+// fireReminder is called once for every claimed reminder, before its
+// remind_at is moved to the next occurrence.
+func fireReminder(reminder models.Reminder) (time.Time, bool) {
+	schedulerLag.Observe(time.Since(reminder.RemindAt).Seconds())
+	return nextOccurrence(reminder)
+}
+
+// nextOccurrence decides what happens to a due reminder. One-shot reminders
+// are retired, recurring ones are moved to their next occurrence.
+func nextOccurrence(reminder models.Reminder) (time.Time, bool) {
+	if reminder.RRule == "" {
+		return time.Time{}, false
+	}
+
+	dtstart := reminder.RemindAt
+	if reminder.RecurrenceStart != nil {
+		dtstart = *reminder.RecurrenceStart
+	}
+
+	// Occurrences missed while the service was down are collapsed into this one.
+	next, ok, err := recurrence.Next(reminder.RRule, reminder.Timezone, dtstart, time.Now())
+	if err != nil {
+		slog.Warn("Invalid recurrence rule, retiring reminder", "reminder_id", reminder.ID, "rrule", reminder.RRule, "error", err)
+		return time.Time{}, false
+	}
+	if ok {
+		slog.Debug("Rescheduling recurring reminder", "reminder_id", reminder.ID, "next", next)
+	}
+	return next, ok
 }
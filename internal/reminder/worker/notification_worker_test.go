@@ -0,0 +1,180 @@
+package worker
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/internal/reminder/storage"
+	"github.com/kiribu/jwt-practice/models"
+)
+
+// upcomingStorage serves GetUpcoming from a fixed list of scheduled reminders
+// and signals every load and claim.
+type upcomingStorage struct {
+	storage.ReminderStorage
+	reminders []models.Reminder
+	loaded    chan struct{}
+	claimed   chan struct{}
+}
+
+func newUpcomingStorage(reminders ...models.Reminder) *upcomingStorage {
+	slices.SortFunc(reminders, func(a, b models.Reminder) int { return a.RemindAt.Compare(b.RemindAt) })
+	return &upcomingStorage{
+		reminders: reminders,
+		loaded:    make(chan struct{}, 1),
+		claimed:   make(chan struct{}, 1),
+	}
+}
+
+func (s *upcomingStorage) GetUpcoming(ctx context.Context, until time.Time, limit int) ([]models.Reminder, error) {
+	var upcoming []models.Reminder
+	for _, reminder := range s.reminders {
+		if reminder.RemindAt.After(until) || len(upcoming) == limit {
+			break
+		}
+		upcoming = append(upcoming, reminder)
+	}
+	signal(s.loaded)
+	return upcoming, nil
+}
+
+func (s *upcomingStorage) ClaimPending(ctx context.Context, limit int, next storage.NextOccurrenceFunc) ([]models.Reminder, error) {
+	signal(s.claimed)
+	return nil, nil
+}
+
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+func waitFor(t *testing.T, ch chan struct{}, what string) {
+	t.Helper()
+	select {
+	case <-ch:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for %s", what)
+	}
+}
+
+func reminderAt(at time.Time) models.Reminder {
+	return models.Reminder{ID: uuid.New(), RemindAt: at, Status: models.ReminderStatusScheduled}
+}
+
+func TestReloadLoadsLookaheadWindow(t *testing.T) {
+	now := time.Now()
+	soon, later, beyond := reminderAt(now.Add(10*time.Minute)), reminderAt(now.Add(30*time.Minute)), reminderAt(now.Add(2*time.Hour))
+	w := NewNotificationWorker(newUpcomingStorage(beyond, later, soon), time.Hour, 10)
+
+	w.reload(context.Background())
+
+	if w.queue.Len() != 2 {
+		t.Errorf("queue has %d reminders, want the 2 inside the window", w.queue.Len())
+	}
+	if _, ok := w.queue.byID[beyond.ID]; ok {
+		t.Error("reminder beyond the window was loaded")
+	}
+	if w.loadedUntil.Before(now.Add(time.Hour)) {
+		t.Errorf("loaded until %s, want the end of the window %s", w.loadedUntil, now.Add(time.Hour))
+	}
+
+	// The timer is armed for the earliest reminder, not the next load
+	if wait := w.nextWakeup(now); wait > 10*time.Minute || wait < 9*time.Minute {
+		t.Errorf("nextWakeup = %s, want about 10m", wait)
+	}
+}
+
+// A window with more reminders than one load holds is only trusted up to the
+// last loaded reminder, and is loaded again from there.
+func TestReloadTruncatedWindow(t *testing.T) {
+	now := time.Now()
+	reminders := make([]models.Reminder, upcomingBatchSize+1)
+	for i := range reminders {
+		reminders[i] = reminderAt(now.Add(time.Minute + time.Duration(i)*time.Second))
+	}
+	w := NewNotificationWorker(newUpcomingStorage(reminders...), time.Hour, 10)
+
+	w.reload(context.Background())
+
+	last := reminders[upcomingBatchSize-1].RemindAt
+	if !w.loadedUntil.Equal(last) {
+		t.Errorf("loaded until %s, want the last loaded reminder %s", w.loadedUntil, last)
+	}
+	if !w.reloadDeadline().Equal(last) {
+		t.Errorf("next load at %s, want %s", w.reloadDeadline(), last)
+	}
+	if w.queue.Len() != upcomingBatchSize {
+		t.Errorf("queue has %d reminders, want %d", w.queue.Len(), upcomingBatchSize)
+	}
+
+	// Past the trusted part of the window, even though inside the lookahead
+	cut := reminders[upcomingBatchSize]
+	w.Schedule(cut.ID, cut.RemindAt)
+	if _, ok := w.queue.byID[cut.ID]; ok {
+		t.Error("reminder after the truncated window was queued")
+	}
+}
+
+func TestScheduleBeyondWindow(t *testing.T) {
+	now := time.Now()
+	w := NewNotificationWorker(newUpcomingStorage(), time.Hour, 10)
+	w.reload(context.Background())
+
+	id := uuid.New()
+	w.Schedule(id, now.Add(2*time.Hour))
+	if w.queue.Len() != 0 {
+		t.Fatalf("queue has %d reminders, want none beyond the window", w.queue.Len())
+	}
+
+	// Moving a queued reminder out of the window drops it
+	w.Schedule(id, now.Add(10*time.Minute))
+	w.Schedule(id, now.Add(2*time.Hour))
+	if w.queue.Len() != 0 {
+		t.Errorf("queue has %d reminders after moving it out of the window, want 0", w.queue.Len())
+	}
+}
+
+func TestNextWakeup(t *testing.T) {
+	now := time.Now()
+	w := NewNotificationWorker(newUpcomingStorage(), time.Hour, 10)
+	w.reload(context.Background())
+
+	if wait, want := w.nextWakeup(now), w.reloadDeadline().Sub(now); wait != want {
+		t.Errorf("nextWakeup with an empty queue = %s, want the next load in %s", wait, want)
+	}
+	w.Schedule(uuid.New(), now.Add(time.Minute))
+	if wait := w.nextWakeup(now); wait != time.Minute {
+		t.Errorf("nextWakeup = %s, want 1m", wait)
+	}
+	w.Schedule(uuid.New(), now.Add(-time.Minute))
+	if wait := w.nextWakeup(now); wait != 0 {
+		t.Errorf("nextWakeup with an overdue reminder = %s, want 0", wait)
+	}
+}
+
+// The loop sleeps until the next load; a reminder scheduled inside the window
+// has to wake it so that it fires on time.
+func TestScheduleWakesLoop(t *testing.T) {
+	store := newUpcomingStorage()
+	w := NewNotificationWorker(store, time.Hour, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		w.Start(ctx)
+		close(done)
+	}()
+	waitFor(t, store.loaded, "the first load")
+
+	w.Schedule(uuid.New(), time.Now().Add(20*time.Millisecond))
+	waitFor(t, store.claimed, "the scheduled reminder to be claimed")
+
+	cancel()
+	<-done
+}
@@ -3,35 +3,56 @@ package worker
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/kiribu/jwt-practice/internal/reminder/kafka"
 	"github.com/kiribu/jwt-practice/internal/reminder/storage"
 	"github.com/kiribu/jwt-practice/models"
+	"github.com/kiribu/jwt-practice/pkg/correlation"
+	"github.com/kiribu/jwt-practice/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// Publisher writes a batch of events to one Kafka topic; see kafka.Producer.SendEvents.
+type Publisher interface {
+	SendEvents(ctx context.Context, events []kafka.Event) []error
+}
+
+// outboxLease is how long a claimed batch stays hidden from other workers. It
+// has to outlast a publish, which the producer bounds at 10 seconds.
+const outboxLease = time.Minute
+
 type OutboxWorker struct {
 	storage              storage.ReminderStorage
-	lifecycleProducer    *kafka.Producer
-	notificationProducer *kafka.Producer
-	interval             time.Duration
+	lifecycleProducer    Publisher
+	notificationProducer Publisher
+	interval             time.Duration   // Fallback poll in case a notification is missed
+	wake                 <-chan struct{} // Signalled by LISTEN on storage.OutboxNotifyChannel
+	backoff              BackoffPolicy
 	batchSize            int
 }
 
 func NewOutboxWorker(
 	storage storage.ReminderStorage,
-	lifecycleProducer *kafka.Producer,
-	notificationProducer *kafka.Producer,
+	lifecycleProducer Publisher,
+	notificationProducer Publisher,
 	interval time.Duration,
+	wake <-chan struct{},
+	backoff BackoffPolicy,
 ) *OutboxWorker {
 	return &OutboxWorker{
 		storage:              storage,
 		lifecycleProducer:    lifecycleProducer,
 		notificationProducer: notificationProducer,
 		interval:             interval,
-		batchSize:            50,
+		wake:                 wake,
+		backoff:              backoff,
+		batchSize:            500, // Matches the producer's Kafka batch size
 	}
 }
 
@@ -41,75 +62,237 @@ func (w *OutboxWorker) Start(ctx context.Context) {
 
 	slog.Info("Outbox Worker started", "interval", w.interval)
 
+	// Pick up whatever was written while the service was down
+	w.drain(ctx)
+
 	for {
 		select {
 		case <-ctx.Done():
 			slog.Info("Stopping Outbox Worker...")
 			return
+		case <-w.wake:
+			w.drain(ctx)
 		case <-ticker.C:
-			w.processOutbox(ctx)
+			w.drain(ctx)
 		}
 	}
 }
 
-func (w *OutboxWorker) processOutbox(ctx context.Context) {
-	events, err := w.storage.GetPendingOutboxEvents(ctx, w.batchSize)
+// drain processes batches until the outbox has no more pending events. It stops
+// early on failures so retries wait for the next wake-up.
+func (w *OutboxWorker) drain(ctx context.Context) {
+	for ctx.Err() == nil && w.processOutbox(ctx) == w.batchSize {
+	}
+}
+
+// processOutbox publishes one batch with a single write per topic and marks
+// everything that was written SENT in one statement, except events of a user
+// that came after one of theirs failed. It returns how many events were sent.
+func (w *OutboxWorker) processOutbox(ctx context.Context) int {
+	events, err := w.storage.ClaimPendingOutboxEvents(ctx, w.batchSize, outboxLease)
 	if err != nil {
 		slog.Error("Error fetching outbox events", "error", err)
-		return
+		return 0
 	}
 
-	if len(events) > 0 {
-		slog.Info("Processing outbox events", "count", len(events))
+	if len(events) == 0 {
+		return 0
 	}
+	slog.Info("Processing outbox events", "count", len(events))
 
-	for _, event := range events {
-		if err := w.processEvent(event); err != nil {
-			slog.Error("Error processing outbox event", "event_id", event.ID, "error", err)
+	ctx, span := tracing.Tracer().Start(ctx, "outbox.process", trace.WithAttributes(attribute.Int("outbox.batch_size", len(events))))
+	defer span.End()
 
-			if err := w.storage.IncrementOutboxRetryCount(ctx, event.ID, err.Error()); err != nil {
-				slog.Error("Failed to update retry count", "event_id", event.ID, "error", err)
-			}
+	// A user's events must reach Kafka in order, so once one of them fails the
+	// user's later events in the batch are left PENDING. ClaimPendingOutboxEvents
+	// then holds them back until the failed event has been retried.
+	blocked := make(map[uuid.UUID]int) // User -> position of their first failed event
+	block := func(pos int, userID uuid.UUID) {
+		if at, ok := blocked[userID]; !ok || pos < at {
+			blocked[userID] = pos
+		}
+	}
+	isBlocked := func(pos int, userID uuid.UUID) bool {
+		at, ok := blocked[userID]
+		return ok && at < pos
+	}
+
+	failed := make(map[int]bool) // Positions rescheduled by fail
+	batches := make(map[Publisher]*publishBatch)
+	var order []Publisher // Publish topics in a stable order
+	for pos, event := range events {
+		if isBlocked(pos, event.UserID) {
+			continue
+		}
+
+		publisher, message, err := w.prepare(event)
+		if err != nil {
+			block(pos, event.UserID)
+			failed[pos] = true
+			w.fail(ctx, event, err)
 			continue
 		}
 
-		if err := w.storage.MarkOutboxEventAsSent(ctx, event.ID); err != nil {
-			slog.Error("Failed to mark event as sent", "event_id", event.ID, "error", err)
+		batch, ok := batches[publisher]
+		if !ok {
+			batch = &publishBatch{}
+			batches[publisher] = batch
+			order = append(order, publisher)
 		}
+		batch.positions = append(batch.positions, pos)
+		batch.messages = append(batch.messages, message)
 	}
-}
 
-func (w *OutboxWorker) processEvent(event models.OutboxEvent) error {
-	key := fmt.Sprintf("%d", event.UserID)
+	var published []int // Positions written to Kafka
+	for _, publisher := range order {
+		batch := batches[publisher].without(events, isBlocked)
+		if len(batch.messages) == 0 {
+			continue
+		}
 
-	switch event.EventType {
-	case "created", "updated", "deleted", "notification_sent":
-		var lifecycleEvent models.LifecycleEvent
-		if err := json.Unmarshal(event.Payload, &lifecycleEvent); err != nil {
-			return fmt.Errorf("failed to unmarshal lifecycle event: %w", err)
+		errs := publisher.SendEvents(ctx, batch.messages)
+		for i, pos := range batch.positions {
+			if errs != nil && errs[i] != nil {
+				// Only the user's first failure counts as an attempt; the
+				// events behind it just wait for it
+				if !isBlocked(pos, events[pos].UserID) {
+					block(pos, events[pos].UserID)
+					failed[pos] = true
+					w.fail(ctx, events[pos], errs[i])
+				}
+				continue
+			}
+			published = append(published, pos)
+		}
+	}
+
+	var sent []uuid.UUID
+	for _, pos := range published {
+		event := events[pos]
+		// Written after an earlier event of the user failed; it is published
+		// again once that one goes through
+		if isBlocked(pos, event.UserID) {
+			continue
 		}
+		sent = append(sent, event.ID)
+		outboxPublishLatency.WithLabelValues(event.EventType).Observe(time.Since(event.CreatedAt).Seconds())
+	}
 
-		if err := w.lifecycleProducer.SendEvent(key, lifecycleEvent); err != nil {
-			return fmt.Errorf("failed to send to lifecycle topic: %w", err)
+	if err := w.storage.MarkOutboxEventsAsSent(ctx, sent); err != nil {
+		// The events stay PENDING and will be published again once the lease ends
+		slog.Error("Failed to mark events as sent", "count", len(sent), "error", err)
+		tracing.End(span, err)
+		return 0
+	}
+
+	// Events held back behind a failure wait for it rather than for the lease
+	var held []uuid.UUID
+	for pos, event := range events {
+		if isBlocked(pos, event.UserID) && !failed[pos] {
+			held = append(held, event.ID)
 		}
+	}
+	if err := w.storage.ReleaseOutboxEvents(ctx, held); err != nil {
+		slog.Error("Failed to release held back events", "count", len(held), "error", err)
+	}
+
+	span.SetAttributes(attribute.Int("outbox.sent", len(sent)))
+
+	slog.Debug("Outbox batch published", "sent", len(sent), "not_sent", len(events)-len(sent))
+	return len(sent)
+}
 
-		slog.Debug("Sent event to reminder_lifecycle", "type", event.EventType, "event_id", event.ID, "reminder_id", event.AggregateID)
+// publishBatch holds the messages for one topic and the positions of their
+// events in the fetched batch.
+type publishBatch struct {
+	positions []int
+	messages  []kafka.Event
+}
+
+// without drops the messages of users blocked by a failure on another topic.
+func (b *publishBatch) without(events []models.OutboxEvent, isBlocked func(int, uuid.UUID) bool) *publishBatch {
+	kept := &publishBatch{}
+	for i, pos := range b.positions {
+		if isBlocked(pos, events[pos].UserID) {
+			continue
+		}
+		kept.positions = append(kept.positions, pos)
+		kept.messages = append(kept.messages, b.messages[i])
+	}
+	return kept
+}
 
+// prepare picks the topic for an event and decodes its envelope.
+func (w *OutboxWorker) prepare(event models.OutboxEvent) (Publisher, kafka.Event, error) {
+	var publisher Publisher
+	switch event.EventType {
+	case "created", "updated", "deleted", "notification_sent", "occurrence_sent", "snoozed", "acknowledged":
+		publisher = w.lifecycleProducer
 	case "notification_trigger":
+		publisher = w.notificationProducer
+	default:
+		return nil, kafka.Event{}, fmt.Errorf("unknown event type: %s", event.EventType)
+	}
+
+	envelope, err := models.ParseEnvelope(event.Payload)
+	if errors.Is(err, models.ErrNoEnvelope) {
+		envelope, err = legacyEnvelope(event)
+	}
+	if err != nil {
+		return nil, kafka.Event{}, err
+	}
+
+	// Keyed by user so all of a user's events, and so every event of a reminder, stay in order
+	return publisher, kafka.Event{
+		Key:           event.UserID.String(),
+		Envelope:      envelope,
+		CorrelationID: event.CorrelationID,
+		TraceParent:   event.TraceParent,
+	}, nil
+}
+
+// legacyEnvelope wraps the payload of rows written before envelopes existed:
+// a bare LifecycleEvent with a reminder snapshot, or a bare Reminder.
+func legacyEnvelope(event models.OutboxEvent) (models.Envelope, error) {
+	if event.EventType == "notification_trigger" {
 		var reminder models.Reminder
 		if err := json.Unmarshal(event.Payload, &reminder); err != nil {
-			return fmt.Errorf("failed to unmarshal reminder: %w", err)
+			return models.Envelope{}, fmt.Errorf("failed to unmarshal reminder: %w", err)
 		}
+		envelope, err := models.NewEnvelope(models.EventTypeNotificationTrigger, reminder.ID, models.NotificationTriggerData{Reminder: reminder})
+		envelope.ID = event.ID // Stable across retries
+		return envelope, err
+	}
 
-		if err := w.notificationProducer.SendEvent(key, reminder); err != nil {
-			return fmt.Errorf("failed to send to notifications topic: %w", err)
-		}
+	var legacy struct {
+		EventID   uuid.UUID        `json:"event_id"`
+		Timestamp time.Time        `json:"timestamp"`
+		Payload   *models.Reminder `json:"payload"`
+	}
+	if err := json.Unmarshal(event.Payload, &legacy); err != nil {
+		return models.Envelope{}, fmt.Errorf("failed to unmarshal lifecycle event: %w", err)
+	}
 
-		slog.Debug("Sent notification_trigger event", "event_id", event.ID, "reminder_id", event.AggregateID)
+	envelope, err := models.NewLifecycleEnvelope(event.EventType, event.AggregateID, event.UserID, legacy.Payload)
+	envelope.ID = legacy.EventID // Analytics has deduplicated by this ID
+	envelope.Time = legacy.Timestamp
+	return envelope, err
+}
 
-	default:
-		return fmt.Errorf("unknown event type: %s", event.EventType)
+// fail records a failed attempt and schedules the retry.
+func (w *OutboxWorker) fail(ctx context.Context, event models.OutboxEvent, err error) {
+	ctx = correlation.WithID(ctx, event.CorrelationID)
+	slog.ErrorContext(ctx, "Error processing outbox event", "event_id", event.ID, "type", event.EventType, "error", err)
+
+	outboxRetries.WithLabelValues(event.EventType).Inc()
+	attempt := event.RetryCount + 1
+	if attempt >= w.backoff.MaxAttempts {
+		slog.ErrorContext(ctx, "Outbox event failed permanently", "event_id", event.ID, "attempts", attempt)
+		outboxFailed.WithLabelValues(event.EventType).Inc()
 	}
 
-	return nil
+	retryAfter := w.backoff.Delay(attempt)
+	if err := w.storage.IncrementOutboxRetryCount(ctx, event.ID, err.Error(), retryAfter, w.backoff.MaxAttempts); err != nil {
+		slog.ErrorContext(ctx, "Failed to update retry count", "event_id", event.ID, "error", err)
+	}
 }
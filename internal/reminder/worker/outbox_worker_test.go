@@ -0,0 +1,395 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/internal/reminder/kafka"
+	"github.com/kiribu/jwt-practice/internal/reminder/storage"
+	"github.com/kiribu/jwt-practice/internal/testdb"
+	"github.com/kiribu/jwt-practice/models"
+)
+
+// outboxStorage keeps the outbox in memory. Like ClaimPendingOutboxEvents, it
+// leases pending events oldest first and holds back a user's events queued
+// behind one that is leased or waiting for a retry.
+type outboxStorage struct {
+	storage.ReminderStorage
+	events  []*models.OutboxEvent
+	retried []uuid.UUID
+}
+
+func (s *outboxStorage) add(t testing.TB, eventType string, userID uuid.UUID) *models.OutboxEvent {
+	t.Helper()
+	reminder := models.Reminder{ID: uuid.New(), UserID: userID, Title: "t", RemindAt: time.Now()}
+	envelope, err := models.NewLifecycleEnvelope(eventType, reminder.ID, userID, &reminder)
+	if err != nil {
+		t.Fatalf("NewLifecycleEnvelope: %v", err)
+	}
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		t.Fatalf("marshal envelope: %v", err)
+	}
+
+	event := &models.OutboxEvent{
+		ID:          envelope.ID,
+		EventType:   eventType,
+		AggregateID: reminder.ID,
+		UserID:      userID,
+		Payload:     payload,
+		Status:      "PENDING",
+		CreatedAt:   time.Now().Add(time.Duration(len(s.events)) * time.Millisecond),
+	}
+	s.events = append(s.events, event)
+	return event
+}
+
+func (s *outboxStorage) ClaimPendingOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEvent, error) {
+	now := time.Now()
+	waiting := make(map[uuid.UUID]bool)
+	var pending []models.OutboxEvent
+	for _, event := range s.events {
+		if event.Status != "PENDING" {
+			continue
+		}
+		if event.NextAttemptAt.After(now) {
+			waiting[event.UserID] = true
+			continue
+		}
+		if waiting[event.UserID] || len(pending) == limit {
+			continue
+		}
+		pending = append(pending, *event)
+	}
+	for _, event := range pending {
+		s.find(event.ID).NextAttemptAt = now.Add(lease)
+	}
+	return pending, nil
+}
+
+func (s *outboxStorage) ReleaseOutboxEvents(ctx context.Context, ids []uuid.UUID) error {
+	for _, id := range ids {
+		s.find(id).NextAttemptAt = time.Now()
+	}
+	return nil
+}
+
+func (s *outboxStorage) MarkOutboxEventsAsSent(ctx context.Context, ids []uuid.UUID) error {
+	for _, id := range ids {
+		s.find(id).Status = "SENT"
+	}
+	return nil
+}
+
+func (s *outboxStorage) IncrementOutboxRetryCount(ctx context.Context, id uuid.UUID, errMsg string, retryAfter time.Duration, maxAttempts int) error {
+	event := s.find(id)
+	event.RetryCount++
+	event.NextAttemptAt = time.Now().Add(retryAfter)
+	s.retried = append(s.retried, id)
+	return nil
+}
+
+func (s *outboxStorage) find(id uuid.UUID) *models.OutboxEvent {
+	for _, event := range s.events {
+		if event.ID == id {
+			return event
+		}
+	}
+	panic("unknown outbox event " + id.String())
+}
+
+// fakePublisher records what reached the topic and fails the events in
+// failing. Like a Kafka produce request, a failure also fails the later
+// messages with the same key, unless partial is set.
+type fakePublisher struct {
+	failing   map[uuid.UUID]bool
+	partial   bool
+	published []uuid.UUID
+}
+
+func (p *fakePublisher) SendEvents(ctx context.Context, events []kafka.Event) []error {
+	var errs []error
+	failedKeys := make(map[string]bool)
+	for i, event := range events {
+		if p.failing[event.Envelope.ID] || (!p.partial && failedKeys[event.Key]) {
+			if errs == nil {
+				errs = make([]error, len(events))
+			}
+			errs[i] = errors.New("broker unavailable")
+			failedKeys[event.Key] = true
+			continue
+		}
+		p.published = append(p.published, event.Envelope.ID)
+	}
+	return errs
+}
+
+func newTestOutboxWorker(store *outboxStorage, publisher Publisher) *OutboxWorker {
+	backoff := BackoffPolicy{Base: time.Hour, Factor: 1, Max: time.Hour, MaxAttempts: 10}
+	return NewOutboxWorker(store, publisher, publisher, time.Second, nil, backoff)
+}
+
+// When "updated" fails, "deleted" must not reach Kafka ahead of it: it stays
+// PENDING until "updated" goes through, and the topic sees them in order.
+func TestProcessOutboxKeepsUserOrderAfterSendFailure(t *testing.T) {
+	store := &outboxStorage{}
+	user, other := uuid.New(), uuid.New()
+	created := store.add(t, "created", user)
+	updated := store.add(t, "updated", user)
+	deleted := store.add(t, "deleted", user)
+	unrelated := store.add(t, "created", other)
+
+	publisher := &fakePublisher{failing: map[uuid.UUID]bool{updated.ID: true}}
+	w := newTestOutboxWorker(store, publisher)
+
+	if sent := w.processOutbox(context.Background()); sent != 2 {
+		t.Errorf("first pass sent %d events, want 2", sent)
+	}
+	if created.Status != "SENT" || unrelated.Status != "SENT" {
+		t.Errorf("statuses = created %s, other user %s, want both SENT", created.Status, unrelated.Status)
+	}
+	if updated.Status != "PENDING" || updated.RetryCount != 1 {
+		t.Errorf("updated = %s after %d retries, want PENDING after 1", updated.Status, updated.RetryCount)
+	}
+	if deleted.Status != "PENDING" || deleted.RetryCount != 0 {
+		t.Errorf("deleted = %s after %d retries, want PENDING untouched", deleted.Status, deleted.RetryCount)
+	}
+
+	// Held back while "updated" waits for its retry
+	if sent := w.processOutbox(context.Background()); sent != 0 {
+		t.Errorf("pass during the backoff sent %d events, want 0", sent)
+	}
+
+	delete(publisher.failing, updated.ID)
+	updated.NextAttemptAt = time.Now()
+	if sent := w.processOutbox(context.Background()); sent != 2 {
+		t.Errorf("retry pass sent %d events, want 2", sent)
+	}
+	if updated.Status != "SENT" || deleted.Status != "SENT" {
+		t.Errorf("statuses = updated %s, deleted %s, want both SENT", updated.Status, deleted.Status)
+	}
+
+	var order []uuid.UUID
+	for _, id := range publisher.published {
+		if store.find(id).UserID == user {
+			order = append(order, id)
+		}
+	}
+	want := []uuid.UUID{created.ID, updated.ID, deleted.ID}
+	if len(order) != len(want) {
+		t.Fatalf("user's events published as %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("user's events published as %v, want created, updated, deleted %v", order, want)
+		}
+	}
+}
+
+// A publisher may write some messages of a batch and fail others. An event
+// written after the user's failed one is not marked SENT, so it is published
+// again behind the failed one instead of being left ahead of it.
+func TestProcessOutboxRepublishesEventsWrittenAfterFailure(t *testing.T) {
+	store := &outboxStorage{}
+	user := uuid.New()
+	updated := store.add(t, "updated", user)
+	deleted := store.add(t, "deleted", user)
+
+	publisher := &fakePublisher{failing: map[uuid.UUID]bool{updated.ID: true}, partial: true}
+	w := newTestOutboxWorker(store, publisher)
+
+	w.processOutbox(context.Background())
+
+	if len(publisher.published) != 1 || publisher.published[0] != deleted.ID {
+		t.Fatalf("published %v, want the partial write of deleted", publisher.published)
+	}
+	if deleted.Status != "PENDING" || deleted.RetryCount != 0 {
+		t.Errorf("deleted = %s after %d retries, want PENDING untouched", deleted.Status, deleted.RetryCount)
+	}
+	if len(store.retried) != 1 || store.retried[0] != updated.ID {
+		t.Errorf("retried %v, want only updated", store.retried)
+	}
+}
+
+// roundTripPublisher stands in for a broker that takes roundTrip to
+// acknowledge each write, however many messages it carries.
+type roundTripPublisher struct {
+	roundTrip time.Duration
+}
+
+func (p roundTripPublisher) SendEvents(ctx context.Context, events []kafka.Event) []error {
+	time.Sleep(p.roundTrip)
+	return nil
+}
+
+// perEventPublisher writes a batch one message at a time, the way the outbox
+// was published before SendEvents.
+type perEventPublisher struct {
+	Publisher
+}
+
+func (p perEventPublisher) SendEvents(ctx context.Context, events []kafka.Event) []error {
+	var errs []error
+	for i, event := range events {
+		if err := p.Publisher.SendEvents(ctx, []kafka.Event{event}); err != nil {
+			if errs == nil {
+				errs = make([]error, len(events))
+			}
+			errs[i] = err[0]
+		}
+	}
+	return errs
+}
+
+func BenchmarkProcessOutbox(b *testing.B) {
+	const users = 50
+	broker := roundTripPublisher{roundTrip: 200 * time.Microsecond}
+
+	for _, bench := range []struct {
+		name      string
+		publisher Publisher
+	}{
+		{"batched", broker},
+		{"per_event", perEventPublisher{broker}},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			store := &outboxStorage{}
+			w := newTestOutboxWorker(store, bench.publisher)
+			for i := range w.batchSize {
+				store.add(b, "created", uuid.NewSHA1(uuid.Nil, []byte{byte(i % users)}))
+			}
+
+			b.ResetTimer()
+			for range b.N {
+				if sent := w.processOutbox(context.Background()); sent != w.batchSize {
+					b.Fatalf("sent %d events, want %d", sent, w.batchSize)
+				}
+
+				b.StopTimer()
+				for _, event := range store.events {
+					event.Status = "PENDING"
+					event.NextAttemptAt = time.Time{}
+				}
+				b.StartTimer()
+			}
+			b.ReportMetric(float64(b.N*w.batchSize)/b.Elapsed().Seconds(), "events/s")
+		})
+	}
+}
+
+// topicLog stands in for the lifecycle topic shared by several workers. It
+// keeps each key's messages in the order they were written, which is the order
+// analytics reads them from the key's partition. Each event listed in
+// failOnce fails on its first write, together with the later messages of its
+// key in the same request.
+type topicLog struct {
+	mu       sync.Mutex
+	failOnce map[string]bool // Envelope type
+	failed   map[string]bool // Reminder ID
+	byKey    map[string][]string
+}
+
+func (l *topicLog) SendEvents(ctx context.Context, events []kafka.Event) []error {
+	time.Sleep(time.Duration(rand.IntN(3)) * time.Millisecond) // Let the workers interleave
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var errs []error
+	failedKeys := make(map[string]bool)
+	for i, event := range events {
+		first := l.failOnce[event.Envelope.Type] && !l.failed[event.Envelope.Subject]
+		if first || failedKeys[event.Key] {
+			if errs == nil {
+				errs = make([]error, len(events))
+			}
+			errs[i] = errors.New("broker unavailable")
+			failedKeys[event.Key] = true
+			if first {
+				l.failed[event.Envelope.Subject] = true
+			}
+			continue
+		}
+		l.byKey[event.Key] = append(l.byKey[event.Key], event.Envelope.Type)
+	}
+	return errs
+}
+
+// Two workers drain the same outbox. Each user's created, updated and deleted
+// events must still reach the topic in that order, also when "updated" fails
+// once and is retried.
+func TestOutboxWorkersKeepUserOrderConcurrently(t *testing.T) {
+	db := testdb.Open(t)
+	store := storage.NewPostgresStorage(db)
+	ctx := context.Background()
+
+	const users = 20
+	for i := range users {
+		user := models.User{ID: uuid.New(), Username: fmt.Sprintf("outbox-%d", i), PasswordHash: "x"}
+		if err := db.Create(&user).Error; err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+		remindAt := time.Now().Add(time.Hour)
+		reminder, err := store.Create(ctx, user.ID, "r", "", remindAt, "", "UTC")
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if _, err := store.Update(ctx, user.ID, reminder.ID, models.ReminderStatusScheduled, "r2", "", remindAt, "", "UTC"); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if err := store.Cancel(ctx, user.ID, reminder.ID, models.ReminderStatusScheduled); err != nil {
+			t.Fatalf("Cancel: %v", err)
+		}
+	}
+
+	topic := &topicLog{
+		failOnce: map[string]bool{models.EventTypeReminderUpdated: true},
+		failed:   make(map[string]bool),
+		byKey:    make(map[string][]string),
+	}
+	backoff := BackoffPolicy{Base: 10 * time.Millisecond, Factor: 1, Max: 10 * time.Millisecond, MaxAttempts: 10}
+
+	deadline := time.Now().Add(30 * time.Second)
+	var wg sync.WaitGroup
+	for range 2 {
+		w := NewOutboxWorker(store, topic, topic, time.Second, nil, backoff)
+		w.batchSize = 4 // Split users across batches and workers
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for time.Now().Before(deadline) {
+				if w.processOutbox(ctx) > 0 {
+					continue
+				}
+				counts, err := store.CountOutboxEventsByStatus(ctx)
+				if err != nil {
+					t.Errorf("CountOutboxEventsByStatus: %v", err)
+					return
+				}
+				if counts["PENDING"] == 0 {
+					return
+				}
+				time.Sleep(5 * time.Millisecond)
+			}
+			t.Error("outbox not drained before the deadline")
+		}()
+	}
+	wg.Wait()
+
+	want := []string{models.EventTypeReminderCreated, models.EventTypeReminderUpdated, models.EventTypeReminderDeleted}
+	if len(topic.byKey) != users {
+		t.Fatalf("topic has events of %d users, want %d", len(topic.byKey), users)
+	}
+	for key, got := range topic.byKey {
+		if !slices.Equal(got, want) {
+			t.Errorf("user %s events on the topic = %v, want %v", key, got, want)
+		}
+	}
+}
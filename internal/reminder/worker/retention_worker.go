@@ -0,0 +1,141 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/kiribu/jwt-practice/internal/reminder/storage"
+)
+
+type RetentionConfig struct {
+	Interval  time.Duration // How often a cleanup run starts
+	MaxAge    time.Duration // SENT events processed longer ago than this are deleted
+	BatchSize int           // Rows deleted per statement, keeps locks and WAL bursts small
+	DryRun    bool          // Only count what would be deleted
+}
+
+// RetentionStats are cumulative since start, except the Last* fields.
+type RetentionStats struct {
+	Runs           int64
+	Purged         int64 // Rows deleted; always 0 in dry-run mode
+	LastPurged     int64
+	LastWouldPurge int64 // Rows the last dry run found old enough to delete
+	LastRunAt      time.Time
+}
+
+// RetentionWorker deletes old SENT events from reminders_outbox in bounded batches.
+type RetentionWorker struct {
+	storage storage.ReminderStorage
+	config  RetentionConfig
+
+	runs           atomic.Int64
+	purged         atomic.Int64
+	lastPurged     atomic.Int64
+	lastWouldPurge atomic.Int64
+	lastRunAt      atomic.Int64 // Unix nanoseconds
+}
+
+func NewRetentionWorker(storage storage.ReminderStorage, config RetentionConfig) *RetentionWorker {
+	if config.Interval <= 0 {
+		config.Interval = time.Hour
+	}
+	if config.MaxAge <= 0 {
+		config.MaxAge = 7 * 24 * time.Hour
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 1000
+	}
+
+	return &RetentionWorker{
+		storage: storage,
+		config:  config,
+	}
+}
+
+func (w *RetentionWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+
+	slog.Info("Retention Worker started",
+		"interval", w.config.Interval,
+		"max_age", w.config.MaxAge,
+		"batch_size", w.config.BatchSize,
+		"dry_run", w.config.DryRun)
+
+	w.purge(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Stopping Retention Worker...")
+			return
+		case <-ticker.C:
+			w.purge(ctx)
+		}
+	}
+}
+
+func (w *RetentionWorker) Stats() RetentionStats {
+	stats := RetentionStats{
+		Runs:           w.runs.Load(),
+		Purged:         w.purged.Load(),
+		LastPurged:     w.lastPurged.Load(),
+		LastWouldPurge: w.lastWouldPurge.Load(),
+	}
+	if ns := w.lastRunAt.Load(); ns != 0 {
+		stats.LastRunAt = time.Unix(0, ns)
+	}
+	return stats
+}
+
+func (w *RetentionWorker) purge(ctx context.Context) {
+	started := time.Now()
+	cutoff := started.Add(-w.config.MaxAge)
+
+	var total int64
+	var err error
+	if w.config.DryRun {
+		// The same rows are counted again on every run, so the count is kept
+		// apart from the purged total instead of being added to it
+		total, err = w.storage.CountSentOutboxEvents(ctx, cutoff)
+		w.lastWouldPurge.Store(total)
+	} else {
+		total, err = w.deleteBatches(ctx, cutoff)
+		w.purged.Add(total)
+		w.lastPurged.Store(total)
+	}
+
+	w.runs.Add(1)
+	w.lastRunAt.Store(started.UnixNano())
+
+	if err != nil {
+		slog.Error("Outbox retention run failed", "purged", total, "error", err)
+		return
+	}
+
+	slog.Info("Outbox retention run finished",
+		"purged", total,
+		"dry_run", w.config.DryRun,
+		"cutoff", cutoff,
+		"duration", time.Since(started),
+		"purged_total", w.purged.Load())
+}
+
+// deleteBatches deletes until a batch comes back short. Each batch is its own
+// statement so no transaction holds locks for the whole run.
+func (w *RetentionWorker) deleteBatches(ctx context.Context, cutoff time.Time) (int64, error) {
+	var total int64
+	for ctx.Err() == nil {
+		deleted, err := w.storage.DeleteSentOutboxEvents(ctx, cutoff, w.config.BatchSize)
+		total += deleted
+		if err != nil {
+			return total, err
+		}
+		if deleted < int64(w.config.BatchSize) {
+			break
+		}
+	}
+	return total, ctx.Err()
+}
@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kiribu/jwt-practice/internal/reminder/storage"
+)
+
+// retentionStorage has old rows to delete in batches.
+type retentionStorage struct {
+	storage.ReminderStorage
+	old int64
+}
+
+func (s *retentionStorage) CountSentOutboxEvents(ctx context.Context, before time.Time) (int64, error) {
+	return s.old, nil
+}
+
+func (s *retentionStorage) DeleteSentOutboxEvents(ctx context.Context, before time.Time, limit int) (int64, error) {
+	deleted := min(s.old, int64(limit))
+	s.old -= deleted
+	return deleted, nil
+}
+
+// Dry runs count the same rows every time; adding them up would make the
+// purged counter grow without anything being deleted.
+func TestRetentionDryRunDoesNotCountAsPurged(t *testing.T) {
+	w := NewRetentionWorker(&retentionStorage{old: 25}, RetentionConfig{BatchSize: 10, DryRun: true})
+
+	w.purge(context.Background())
+	w.purge(context.Background())
+
+	stats := w.Stats()
+	if stats.Purged != 0 || stats.LastPurged != 0 {
+		t.Errorf("purged = %d, last = %d after dry runs, want 0", stats.Purged, stats.LastPurged)
+	}
+	if stats.LastWouldPurge != 25 || stats.Runs != 2 {
+		t.Errorf("would purge %d in %d runs, want 25 in 2", stats.LastWouldPurge, stats.Runs)
+	}
+}
+
+func TestRetentionPurgesInBatches(t *testing.T) {
+	store := &retentionStorage{old: 25}
+	w := NewRetentionWorker(store, RetentionConfig{BatchSize: 10})
+
+	w.purge(context.Background())
+
+	if stats := w.Stats(); stats.Purged != 25 || stats.LastPurged != 25 || stats.LastWouldPurge != 0 {
+		t.Errorf("stats = %+v, want 25 purged", stats)
+	}
+	if store.old != 0 {
+		t.Errorf("%d old rows left, want 0", store.old)
+	}
+}
@@ -0,0 +1,92 @@
+package worker
+
+import (
+	"container/heap"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type scheduleEntry struct {
+	id       uuid.UUID
+	remindAt time.Time
+	index    int
+}
+
+// scheduleQueue is a min-heap of reminders ordered by remind_at with O(log n)
+// updates by ID. It is not safe for concurrent use.
+type scheduleQueue struct {
+	entries []*scheduleEntry
+	byID    map[uuid.UUID]*scheduleEntry
+}
+
+func newScheduleQueue() *scheduleQueue {
+	return &scheduleQueue{byID: make(map[uuid.UUID]*scheduleEntry)}
+}
+
+func (q *scheduleQueue) Len() int { return len(q.entries) }
+
+func (q *scheduleQueue) Less(i, j int) bool {
+	return q.entries[i].remindAt.Before(q.entries[j].remindAt)
+}
+
+func (q *scheduleQueue) Swap(i, j int) {
+	q.entries[i], q.entries[j] = q.entries[j], q.entries[i]
+	q.entries[i].index = i
+	q.entries[j].index = j
+}
+
+func (q *scheduleQueue) Push(x any) {
+	entry := x.(*scheduleEntry)
+	entry.index = len(q.entries)
+	q.entries = append(q.entries, entry)
+}
+
+func (q *scheduleQueue) Pop() any {
+	last := len(q.entries) - 1
+	entry := q.entries[last]
+	q.entries[last] = nil
+	q.entries = q.entries[:last]
+	entry.index = -1
+	return entry
+}
+
+// set adds the reminder or moves it to a new remind_at.
+func (q *scheduleQueue) set(id uuid.UUID, remindAt time.Time) {
+	if entry, ok := q.byID[id]; ok {
+		entry.remindAt = remindAt
+		heap.Fix(q, entry.index)
+		return
+	}
+	entry := &scheduleEntry{id: id, remindAt: remindAt}
+	q.byID[id] = entry
+	heap.Push(q, entry)
+}
+
+func (q *scheduleQueue) remove(id uuid.UUID) {
+	entry, ok := q.byID[id]
+	if !ok {
+		return
+	}
+	heap.Remove(q, entry.index)
+	delete(q.byID, id)
+}
+
+// next returns the earliest remind_at in the queue.
+func (q *scheduleQueue) next() (time.Time, bool) {
+	if len(q.entries) == 0 {
+		return time.Time{}, false
+	}
+	return q.entries[0].remindAt, true
+}
+
+// popDue removes every entry due at or before now and reports whether there was any.
+func (q *scheduleQueue) popDue(now time.Time) bool {
+	popped := false
+	for len(q.entries) > 0 && !q.entries[0].remindAt.After(now) {
+		entry := heap.Pop(q).(*scheduleEntry)
+		delete(q.byID, entry.id)
+		popped = true
+	}
+	return popped
+}
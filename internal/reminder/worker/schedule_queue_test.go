@@ -0,0 +1,104 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// popOrder pops the queue one entry at a time and returns the IDs in the
+// order they came due.
+func popOrder(t *testing.T, q *scheduleQueue) []uuid.UUID {
+	t.Helper()
+	var order []uuid.UUID
+	for {
+		at, ok := q.next()
+		if !ok {
+			return order
+		}
+		id := q.entries[0].id
+		if !q.popDue(at) {
+			t.Fatalf("popDue(%s) popped nothing", at)
+		}
+		if _, ok := q.byID[id]; ok {
+			t.Fatalf("popped %s is still indexed", id)
+		}
+		order = append(order, id)
+	}
+}
+
+func TestScheduleQueueOrdersByRemindAt(t *testing.T) {
+	base := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	ids := make([]uuid.UUID, 5)
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+
+	q := newScheduleQueue()
+	for _, i := range []int{3, 0, 4, 1, 2} {
+		q.set(ids[i], base.Add(time.Duration(i)*time.Minute))
+	}
+	q.remove(ids[1])
+	q.remove(uuid.New()) // Unknown IDs are ignored
+
+	want := []uuid.UUID{ids[0], ids[2], ids[3], ids[4]}
+	got := popOrder(t, q)
+	if len(got) != len(want) {
+		t.Fatalf("popped %d entries, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("entry %d = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestScheduleQueuePopDue(t *testing.T) {
+	base := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	q := newScheduleQueue()
+	for i := range 4 {
+		q.set(uuid.New(), base.Add(time.Duration(i)*time.Minute))
+	}
+
+	if q.popDue(base.Add(-time.Second)) {
+		t.Error("popDue before the earliest entry popped something")
+	}
+	// Entries due exactly at now are popped too
+	if !q.popDue(base.Add(time.Minute)) {
+		t.Fatal("popDue popped nothing")
+	}
+	if q.Len() != 2 || len(q.byID) != 2 {
+		t.Errorf("%d entries left (%d indexed), want 2", q.Len(), len(q.byID))
+	}
+	if next, _ := q.next(); !next.Equal(base.Add(2 * time.Minute)) {
+		t.Errorf("next = %s, want %s", next, base.Add(2*time.Minute))
+	}
+}
+
+// Editing or snoozing a reminder moves its entry instead of adding a second one.
+func TestScheduleQueueSetReplacesEntry(t *testing.T) {
+	base := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	q := newScheduleQueue()
+	a, b := uuid.New(), uuid.New()
+	q.set(a, base)
+	q.set(b, base.Add(time.Minute))
+
+	q.set(a, base.Add(2*time.Minute))
+	if q.Len() != 2 {
+		t.Fatalf("%d entries after moving one, want 2", q.Len())
+	}
+	if next, _ := q.next(); !next.Equal(base.Add(time.Minute)) {
+		t.Errorf("next after moving a later = %s, want b at %s", next, base.Add(time.Minute))
+	}
+
+	q.set(a, base.Add(-time.Minute))
+	if next, _ := q.next(); !next.Equal(base.Add(-time.Minute)) {
+		t.Errorf("next after moving a earlier = %s, want %s", next, base.Add(-time.Minute))
+	}
+
+	got := popOrder(t, q)
+	if len(got) != 2 || got[0] != a || got[1] != b {
+		t.Errorf("popped %v, want [%s %s]", got, a, b)
+	}
+}
@@ -0,0 +1,103 @@
+// Package testdb gives tests a migrated PostgreSQL schema of their own.
+// Tests that need it are skipped unless TEST_DATABASE_URL points at a server,
+// for example "host=localhost user=postgres password=postgres dbname=postgres sslmode=disable".
+package testdb
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Open creates a fresh schema, applies migrations/init to it and returns a
+// connection whose search_path is that schema. The schema is dropped when
+// the test ends.
+func Open(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	admin, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("testdb: connect: %v", err)
+	}
+
+	schema := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	if err := admin.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+		t.Fatalf("testdb: create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+		if sqlDB, err := admin.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	db, err := gorm.Open(postgres.Open(withSearchPath(dsn, schema)), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("testdb: connect to schema: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	files, err := filepath.Glob(filepath.Join(repoRoot(t), "migrations", "init", "*.up.sql"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("testdb: no migrations found: %v", err)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		sql, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("testdb: read %s: %v", file, err)
+		}
+		if err := db.Exec(string(sql)).Error; err != nil {
+			t.Fatalf("testdb: apply %s: %v", filepath.Base(file), err)
+		}
+	}
+
+	return db
+}
+
+// withSearchPath adds search_path to a URL or key/value connection string.
+func withSearchPath(dsn, schema string) string {
+	if strings.Contains(dsn, "://") {
+		sep := "?"
+		if strings.Contains(dsn, "?") {
+			sep = "&"
+		}
+		return dsn + sep + "search_path=" + schema
+	}
+	return fmt.Sprintf("%s search_path=%s", dsn, schema)
+}
+
+// repoRoot walks up from the test's working directory to the go.mod.
+func repoRoot(t testing.TB) string {
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("testdb: %v", err)
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			t.Fatal("testdb: go.mod not found")
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,4 @@
+ALTER TABLE reminders
+    DROP COLUMN IF EXISTS recurrence_start,
+    DROP COLUMN IF EXISTS timezone,
+    DROP COLUMN IF EXISTS rrule;
@@ -0,0 +1,4 @@
+ALTER TABLE reminders
+    ADD COLUMN IF NOT EXISTS rrule            TEXT,
+    ADD COLUMN IF NOT EXISTS timezone         VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS recurrence_start TIMESTAMPTZ;
@@ -0,0 +1,3 @@
+ALTER TABLE analytics.user_statistics
+    DROP COLUMN IF EXISTS total_reminders_acknowledged,
+    DROP COLUMN IF EXISTS total_reminders_snoozed;
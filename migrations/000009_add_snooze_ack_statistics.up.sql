@@ -0,0 +1,3 @@
+ALTER TABLE analytics.user_statistics
+    ADD COLUMN IF NOT EXISTS total_reminders_snoozed INT DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS total_reminders_acknowledged INT DEFAULT 0;
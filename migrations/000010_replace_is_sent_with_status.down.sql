@@ -0,0 +1,14 @@
+ALTER TABLE reminders
+    ADD COLUMN IF NOT EXISTS is_sent BOOLEAN DEFAULT FALSE;
+
+UPDATE reminders SET is_sent = status NOT IN ('scheduled', 'cancelled');
+
+-- Cancelled reminders were hard-deleted before the status column existed
+DELETE FROM reminders WHERE status = 'cancelled';
+
+DROP INDEX IF EXISTS idx_reminders_user_status;
+DROP INDEX IF EXISTS idx_reminders_due;
+CREATE INDEX idx_reminders_due ON reminders(remind_at) WHERE is_sent = FALSE;
+
+ALTER TABLE reminders DROP CONSTRAINT IF EXISTS chk_reminders_status;
+ALTER TABLE reminders DROP COLUMN IF EXISTS status;
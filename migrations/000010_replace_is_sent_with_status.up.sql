@@ -0,0 +1,20 @@
+ALTER TABLE reminders
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'scheduled';
+
+-- Rows sent before the status column existed were handed to notification-service,
+-- which had no failure path, so they are treated as delivered.
+UPDATE reminders SET status = CASE
+    WHEN acknowledged_at IS NOT NULL THEN 'acknowledged'
+    WHEN is_sent THEN 'delivered'
+    ELSE 'scheduled'
+END;
+
+ALTER TABLE reminders
+    ADD CONSTRAINT chk_reminders_status
+    CHECK (status IN ('scheduled', 'queued', 'delivered', 'failed', 'acknowledged', 'cancelled'));
+
+DROP INDEX IF EXISTS idx_reminders_due;
+CREATE INDEX idx_reminders_due ON reminders(remind_at) WHERE status = 'scheduled';
+CREATE INDEX IF NOT EXISTS idx_reminders_user_status ON reminders(user_id, status);
+
+ALTER TABLE reminders DROP COLUMN IF EXISTS is_sent;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_reminder_deliveries_reminder;
+DROP TABLE IF EXISTS reminder_deliveries;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS reminder_deliveries (
+    id           UUID PRIMARY KEY,
+    reminder_id  UUID NOT NULL REFERENCES reminders(id) ON DELETE CASCADE,
+    user_id      UUID NOT NULL,
+    status       VARCHAR(20) NOT NULL,
+    channel      VARCHAR(50) NOT NULL,
+    reason       TEXT,
+    attempted_at TIMESTAMPTZ NOT NULL,
+    created_at   TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_reminder_deliveries_reminder ON reminder_deliveries(reminder_id, attempted_at);
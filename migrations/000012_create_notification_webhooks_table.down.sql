@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_notification_webhooks_user_id;
+DROP TABLE IF EXISTS notification_webhooks;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_notification_deferred_deliver_at;
+DROP TABLE IF EXISTS notification_deferred;
+DROP TABLE IF EXISTS notification_preferences;
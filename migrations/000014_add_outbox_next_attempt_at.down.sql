@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON reminders_outbox(status, created_at)
+WHERE status = 'PENDING';
+
+ALTER TABLE reminders_outbox
+    DROP COLUMN IF EXISTS next_attempt_at;
@@ -0,0 +1,6 @@
+ALTER TABLE reminders_outbox
+    ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON reminders_outbox(status, next_attempt_at)
+WHERE status = 'PENDING';
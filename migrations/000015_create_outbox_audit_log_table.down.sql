@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_outbox_failed;
+DROP INDEX IF EXISTS idx_outbox_audit_log_created_at;
+DROP TABLE IF EXISTS outbox_audit_log;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS outbox_audit_log (
+    id         UUID PRIMARY KEY,
+    action     VARCHAR(20) NOT NULL,
+    event_id   UUID NOT NULL,
+    actor      VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_audit_log_created_at ON outbox_audit_log(created_at);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_failed ON reminders_outbox(created_at)
+WHERE status = 'FAILED';
@@ -0,0 +1,2 @@
+ALTER TABLE reminders_outbox DROP COLUMN IF EXISTS correlation_id;
+ALTER TABLE reminders DROP COLUMN IF EXISTS correlation_id;
@@ -0,0 +1,8 @@
+-- Hashes cannot be turned back into tokens
+DELETE FROM refresh_tokens;
+
+DROP INDEX IF EXISTS idx_refresh_tokens_token_hash;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS token_hash;
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS token VARCHAR(512) UNIQUE NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_token ON refresh_tokens(token);
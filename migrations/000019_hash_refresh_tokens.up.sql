@@ -0,0 +1,9 @@
+-- Refresh tokens are stored as HMAC-SHA256(pepper, token). Existing rows hold
+-- raw tokens and the pepper is not available to SQL, so they are invalidated;
+-- their users log in again.
+DELETE FROM refresh_tokens;
+
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS token;
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS token_hash CHAR(64) NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refresh_tokens_token_hash ON refresh_tokens(token_hash);
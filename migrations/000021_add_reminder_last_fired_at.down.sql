@@ -0,0 +1,2 @@
+ALTER TABLE reminders
+    DROP COLUMN IF EXISTS last_fired_at;
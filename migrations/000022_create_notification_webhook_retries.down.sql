@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_notification_webhook_retries_due;
+DROP TABLE IF EXISTS notification_webhook_retries;
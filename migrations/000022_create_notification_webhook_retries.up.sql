@@ -0,0 +1,17 @@
+-- Failed webhook deliveries waiting for their next attempt. The retry worker
+-- claims due rows by pushing next_attempt_at forward, so replicas never retry
+-- the same row at once.
+CREATE TABLE IF NOT EXISTS notification_webhook_retries (
+    id              UUID PRIMARY KEY,
+    webhook_id      UUID NOT NULL REFERENCES notification_webhooks(id) ON DELETE CASCADE,
+    reminder_id     UUID NOT NULL,
+    user_id         UUID NOT NULL,
+    event_id        UUID NOT NULL,
+    payload         JSONB NOT NULL,
+    attempts        INT NOT NULL,
+    last_error      TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    created_at      TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_webhook_retries_due ON notification_webhook_retries(next_attempt_at);
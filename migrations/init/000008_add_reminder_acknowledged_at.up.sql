@@ -0,0 +1,2 @@
+ALTER TABLE reminders
+    ADD COLUMN IF NOT EXISTS acknowledged_at TIMESTAMPTZ;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id           UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    channels          JSONB NOT NULL DEFAULT '[]',
+    quiet_hours_start VARCHAR(5),
+    quiet_hours_end   VARCHAR(5),
+    timezone          VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    updated_at        TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS notification_deferred (
+    id          UUID PRIMARY KEY,
+    reminder_id UUID NOT NULL,
+    user_id     UUID NOT NULL,
+    payload     JSONB NOT NULL,
+    deliver_at  TIMESTAMPTZ NOT NULL,
+    created_at  TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_deferred_deliver_at ON notification_deferred(deliver_at);
@@ -0,0 +1,2 @@
+ALTER TABLE reminders ADD COLUMN IF NOT EXISTS correlation_id VARCHAR(64);
+ALTER TABLE reminders_outbox ADD COLUMN IF NOT EXISTS correlation_id VARCHAR(64);
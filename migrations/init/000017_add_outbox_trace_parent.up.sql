@@ -0,0 +1 @@
+ALTER TABLE reminders_outbox ADD COLUMN IF NOT EXISTS trace_parent VARCHAR(55);
@@ -0,0 +1,4 @@
+-- The occurrence that fired last. Recurring reminders stay scheduled after
+-- firing, so this is what lets that occurrence be snoozed or acknowledged.
+ALTER TABLE reminders
+    ADD COLUMN IF NOT EXISTS last_fired_at TIMESTAMPTZ;
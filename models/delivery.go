@@ -0,0 +1,64 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusFailed    = "failed"
+)
+
+// NotificationResult is published by notification-service to the
+// notification_results topic after every delivery attempt.
+type NotificationResult struct {
+	ResultID   uuid.UUID `json:"result_id"` // Unique ID for idempotency
+	ReminderID uuid.UUID `json:"reminder_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Status     string    `json:"status"` // "delivered" or "failed"
+	Channel    string    `json:"channel"`
+	Reason     string    `json:"reason,omitempty"` // Failure reason
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// NewNotificationResultEnvelope wraps a delivery receipt for the
+// notification_results topic.
+func NewNotificationResultEnvelope(result NotificationResult) (Envelope, error) {
+	envelope, err := NewEnvelope(EventTypeNotificationResult, result.ReminderID, result)
+	envelope.ID = result.ResultID // Stable across publish retries
+	envelope.Source = NotificationEventSource
+	envelope.Time = result.Timestamp
+	return envelope, err
+}
+
+// NotificationResultFromEnvelope reads a v1 notification.result envelope.
+func NotificationResultFromEnvelope(env Envelope) (NotificationResult, error) {
+	var result NotificationResult
+	if env.Type != EventTypeNotificationResult {
+		return result, fmt.Errorf("%w: type %q", ErrUnsupportedEvent, env.Type)
+	}
+	if err := json.Unmarshal(env.Data, &result); err != nil {
+		return result, fmt.Errorf("failed to unmarshal %s data: %w", env.Type, err)
+	}
+	return result, nil
+}
+
+// DeliveryAttempt is the reminder-service record of a NotificationResult.
+type DeliveryAttempt struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"` // NotificationResult.ResultID
+	ReminderID  uuid.UUID `gorm:"type:uuid;not null;index" json:"reminder_id"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null" json:"user_id"`
+	Status      string    `gorm:"type:varchar(20);not null" json:"status"`
+	Channel     string    `gorm:"type:varchar(50);not null" json:"channel"`
+	Reason      string    `gorm:"type:text" json:"reason,omitempty"`
+	AttemptedAt time.Time `gorm:"type:timestamptz;not null" json:"attempted_at"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (DeliveryAttempt) TableName() string {
+	return "reminder_deliveries"
+}
@@ -0,0 +1,157 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CloudEvents attributes shared by every message on the reminder topics.
+const (
+	EventSpecVersion        = "1.0"
+	EventSource             = "/reminder-service"
+	NotificationEventSource = "/notification-service" // Delivery receipts
+	EventDataContentType    = "application/json"
+)
+
+// Event types carry the data version as a suffix. A breaking change to the
+// data gets a new type (".v2") that consumers must opt in to.
+const (
+	EventTypeReminderCreated      = "com.kiribu.reminders.reminder.created.v1"
+	EventTypeReminderUpdated      = "com.kiribu.reminders.reminder.updated.v1"
+	EventTypeReminderDeleted      = "com.kiribu.reminders.reminder.deleted.v1"
+	EventTypeReminderSnoozed      = "com.kiribu.reminders.reminder.snoozed.v1"
+	EventTypeReminderAcknowledged = "com.kiribu.reminders.reminder.acknowledged.v1"
+	EventTypeNotificationSent     = "com.kiribu.reminders.reminder.notification_sent.v1"
+	EventTypeOccurrenceSent       = "com.kiribu.reminders.reminder.occurrence_sent.v1"
+	EventTypeNotificationTrigger  = "com.kiribu.reminders.notification.trigger.v1"
+	EventTypeNotificationResult   = "com.kiribu.reminders.notification.result.v1"
+)
+
+const eventDataSchemaPrefix = "urn:kiribu:reminders:schema:"
+
+var (
+	// ErrUnsupportedEvent is returned for envelopes a consumer does not know how to read.
+	ErrUnsupportedEvent = errors.New("unsupported event")
+	// ErrNoEnvelope is returned for messages written before envelopes were introduced.
+	ErrNoEnvelope = errors.New("message has no envelope")
+)
+
+// lifecycleEventTypes maps outbox event types to their CloudEvents type.
+var lifecycleEventTypes = map[string]string{
+	"created":           EventTypeReminderCreated,
+	"updated":           EventTypeReminderUpdated,
+	"deleted":           EventTypeReminderDeleted,
+	"snoozed":           EventTypeReminderSnoozed,
+	"acknowledged":      EventTypeReminderAcknowledged,
+	"notification_sent": EventTypeNotificationSent,
+	"occurrence_sent":   EventTypeOccurrenceSent,
+}
+
+// Envelope is a CloudEvents 1.0 structured-mode event.
+type Envelope struct {
+	ID              uuid.UUID       `json:"id"` // Unique ID for idempotency
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	SpecVersion     string          `json:"specversion"`
+	Time            time.Time       `json:"time"`
+	DataSchema      string          `json:"dataschema"`
+	DataContentType string          `json:"datacontenttype"`
+	Subject         string          `json:"subject,omitempty"` // Reminder ID
+	Data            json.RawMessage `json:"data"`
+}
+
+// ReminderEventData is the data of every reminder lifecycle event except deleted.
+type ReminderEventData struct {
+	ReminderID uuid.UUID `json:"reminder_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Reminder   Reminder  `json:"reminder"` // Snapshot after the change
+}
+
+// ReminderDeletedData is the data of a reminder.deleted event.
+type ReminderDeletedData struct {
+	ReminderID uuid.UUID `json:"reminder_id"`
+	UserID     uuid.UUID `json:"user_id"`
+}
+
+// NotificationTriggerData asks notification-service to deliver a reminder.
+type NotificationTriggerData struct {
+	Reminder Reminder `json:"reminder"`
+}
+
+// NewEnvelope wraps data in an envelope with a fresh ID.
+func NewEnvelope(eventType string, subject uuid.UUID, data interface{}) (Envelope, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("failed to marshal event data: %w", err)
+	}
+
+	return Envelope{
+		ID:              uuid.Must(uuid.NewV7()),
+		Source:          EventSource,
+		Type:            eventType,
+		SpecVersion:     EventSpecVersion,
+		Time:            time.Now(),
+		DataSchema:      eventDataSchemaPrefix + eventType,
+		DataContentType: EventDataContentType,
+		Subject:         subject.String(),
+		Data:            raw,
+	}, nil
+}
+
+// NewLifecycleEnvelope builds the envelope for an outbox lifecycle event type
+// such as "created". A nil reminder is only valid for "deleted".
+func NewLifecycleEnvelope(eventType string, reminderID, userID uuid.UUID, reminder *Reminder) (Envelope, error) {
+	cloudType, ok := lifecycleEventTypes[eventType]
+	if !ok {
+		return Envelope{}, fmt.Errorf("%w: lifecycle type %q", ErrUnsupportedEvent, eventType)
+	}
+
+	if reminder == nil {
+		return NewEnvelope(cloudType, reminderID, ReminderDeletedData{ReminderID: reminderID, UserID: userID})
+	}
+	return NewEnvelope(cloudType, reminderID, ReminderEventData{ReminderID: reminderID, UserID: userID, Reminder: *reminder})
+}
+
+// ParseEnvelope decodes an envelope and rejects spec versions other than 1.0.
+func ParseEnvelope(raw []byte) (Envelope, error) {
+	var env Envelope
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return Envelope{}, fmt.Errorf("failed to unmarshal envelope: %w", err)
+	}
+	if env.SpecVersion == "" {
+		return Envelope{}, ErrNoEnvelope
+	}
+	if env.SpecVersion != EventSpecVersion {
+		return Envelope{}, fmt.Errorf("%w: specversion %q", ErrUnsupportedEvent, env.SpecVersion)
+	}
+	return env, nil
+}
+
+// LifecycleEventFromEnvelope converts a v1 reminder lifecycle envelope to the
+// flat form analytics-service works with.
+func LifecycleEventFromEnvelope(env Envelope) (LifecycleEvent, error) {
+	for eventType, cloudType := range lifecycleEventTypes {
+		if cloudType != env.Type {
+			continue
+		}
+
+		// Every lifecycle data type starts with reminder_id and user_id
+		var data ReminderDeletedData
+		if err := json.Unmarshal(env.Data, &data); err != nil {
+			return LifecycleEvent{}, fmt.Errorf("failed to unmarshal %s data: %w", env.Type, err)
+		}
+
+		return LifecycleEvent{
+			EventID:    env.ID,
+			EventType:  eventType,
+			ReminderID: data.ReminderID,
+			UserID:     data.UserID,
+			Timestamp:  env.Time,
+		}, nil
+	}
+	return LifecycleEvent{}, fmt.Errorf("%w: type %q", ErrUnsupportedEvent, env.Type)
+}
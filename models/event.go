@@ -6,11 +6,12 @@ import (
 	"github.com/google/uuid"
 )
 
+// LifecycleEvent is a reminder lifecycle event as analytics-service sees it,
+// decoded from the Kafka envelope by LifecycleEventFromEnvelope.
 type LifecycleEvent struct {
-	EventID    uuid.UUID   `json:"event_id"`   // Unique ID for idempotency
-	EventType  string      `json:"event_type"` // "created", "updated", "deleted", "notification_sent"
-	ReminderID uuid.UUID   `json:"reminder_id"`
-	UserID     uuid.UUID   `json:"user_id"`
-	Timestamp  time.Time   `json:"timestamp"`
-	Payload    interface{} `json:"payload,omitempty"` // Reminder snapshot or nil
+	EventID    uuid.UUID `json:"event_id"`   // Unique ID for idempotency
+	EventType  string    `json:"event_type"` // "created", "updated", "deleted", "notification_sent", "occurrence_sent", "snoozed", "acknowledged"
+	ReminderID uuid.UUID `json:"reminder_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Timestamp  time.Time `json:"timestamp"`
 }
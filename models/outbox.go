@@ -8,18 +8,39 @@ import (
 )
 
 type OutboxEvent struct {
-	ID           uuid.UUID       `gorm:"type:uuid;primaryKey" json:"id"`
-	EventType    string          `gorm:"type:varchar(50);not null" json:"event_type"`
-	AggregateID  uuid.UUID       `gorm:"type:uuid" json:"aggregate_id"`
-	UserID       uuid.UUID       `gorm:"type:uuid;not null" json:"user_id"`
-	Payload      json.RawMessage `gorm:"type:jsonb;not null" json:"payload"`
-	Status       string          `gorm:"type:varchar(20);default:'PENDING'" json:"status"`
-	RetryCount   int             `gorm:"default:0" json:"retry_count"`
-	CreatedAt    time.Time       `gorm:"autoCreateTime" json:"created_at"`
-	ProcessedAt  *time.Time      `json:"processed_at"`
-	ErrorMessage *string         `gorm:"type:text" json:"error_message"`
+	ID            uuid.UUID       `gorm:"type:uuid;primaryKey" json:"id"`
+	EventType     string          `gorm:"type:varchar(50);not null" json:"event_type"`
+	AggregateID   uuid.UUID       `gorm:"type:uuid" json:"aggregate_id"`
+	UserID        uuid.UUID       `gorm:"type:uuid;not null" json:"user_id"`
+	Payload       json.RawMessage `gorm:"type:jsonb;not null" json:"payload"`
+	Status        string          `gorm:"type:varchar(20);default:'PENDING'" json:"status"`
+	RetryCount    int             `gorm:"default:0" json:"retry_count"`
+	NextAttemptAt time.Time       `gorm:"not null;default:now()" json:"next_attempt_at"` // Not picked up before this
+	CreatedAt     time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	ProcessedAt   *time.Time      `json:"processed_at"`
+	ErrorMessage  *string         `gorm:"type:text" json:"error_message"`
+	CorrelationID string          `gorm:"type:varchar(64)" json:"correlation_id,omitempty"`
+	TraceParent   string          `gorm:"type:varchar(55)" json:"-"` // W3C traceparent of the span that wrote the event
 }
 
 func (OutboxEvent) TableName() string {
 	return "reminders_outbox"
 }
+
+const (
+	OutboxAuditActionRequeue = "requeue"
+	OutboxAuditActionDiscard = "discard"
+)
+
+// OutboxAuditEntry records an operator action on a dead-lettered outbox event.
+type OutboxAuditEntry struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Action    string    `gorm:"type:varchar(20);not null" json:"action"`
+	EventID   uuid.UUID `gorm:"type:uuid;not null" json:"event_id"`
+	Actor     string    `gorm:"type:varchar(255);not null" json:"actor"` // Username of the admin
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (OutboxAuditEntry) TableName() string {
+	return "outbox_audit_log"
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChannelPreference enables one delivery channel for a user.
+type ChannelPreference struct {
+	Channel string `json:"channel"`
+	Address string `json:"address,omitempty"` // Channel-specific recipient, e.g. an email address
+}
+
+// ChannelPreferences is stored as a jsonb array.
+type ChannelPreferences []ChannelPreference
+
+func (c ChannelPreferences) Value() (driver.Value, error) {
+	if c == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (c *ChannelPreferences) Scan(value any) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	case nil:
+		*c = nil
+		return nil
+	default:
+		return errors.New("unsupported type for ChannelPreferences")
+	}
+}
+
+// NotificationPreferences controls how and when a user is notified.
+// Users without a row get every channel of the instance and no quiet hours.
+type NotificationPreferences struct {
+	UserID          uuid.UUID          `gorm:"type:uuid;primaryKey" json:"user_id"`
+	Channels        ChannelPreferences `gorm:"type:jsonb;not null" json:"channels"`      // Empty means every channel of the instance
+	QuietHoursStart string             `gorm:"type:varchar(5)" json:"quiet_hours_start"` // "HH:MM" in Timezone, empty disables quiet hours
+	QuietHoursEnd   string             `gorm:"type:varchar(5)" json:"quiet_hours_end"`
+	Timezone        string             `gorm:"type:varchar(64);not null;default:'UTC'" json:"timezone"`
+	UpdatedAt       time.Time          `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (NotificationPreferences) TableName() string {
+	return "notification_preferences"
+}
+
+// DeferredDelivery is a reminder held back by quiet hours until DeliverAt.
+type DeferredDelivery struct {
+	ID         uuid.UUID       `gorm:"type:uuid;primaryKey" json:"id"`
+	ReminderID uuid.UUID       `gorm:"type:uuid;not null" json:"reminder_id"`
+	UserID     uuid.UUID       `gorm:"type:uuid;not null" json:"user_id"`
+	Payload    json.RawMessage `gorm:"type:jsonb;not null" json:"payload"` // The notifications topic message
+	DeliverAt  time.Time       `gorm:"not null;index" json:"deliver_at"`
+	CreatedAt  time.Time       `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (DeferredDelivery) TableName() string {
+	return "notification_deferred"
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// PushChannel is the Redis pub/sub channel notification-service publishes
+	// live PushEvents to; the gateway fans them out to SSE connections.
+	PushChannel = "reminder_events"
+
+	PushEventReminderDelivered = "reminder.delivered"
+)
+
+// PushStreamKey is the per-user Redis stream that keeps recent PushEvents so
+// that reconnecting clients can catch up from their Last-Event-ID.
+func PushStreamKey(userID uuid.UUID) string {
+	return "reminder_events:" + userID.String()
+}
+
+// PushEvent is sent to the user's browser when a reminder is delivered.
+type PushEvent struct {
+	ID          string    `json:"id,omitempty"` // Redis stream entry ID, doubles as the SSE event ID
+	Type        string    `json:"type"`
+	ReminderID  uuid.UUID `json:"reminder_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	RemindAt    time.Time `json:"remind_at"`
+	Timestamp   time.Time `json:"timestamp"`
+}
@@ -6,15 +6,32 @@ import (
 	"github.com/google/uuid"
 )
 
+type ReminderStatus string
+
+const (
+	ReminderStatusScheduled    ReminderStatus = "scheduled"    // waiting for remind_at
+	ReminderStatusQueued       ReminderStatus = "queued"       // notification_trigger written to the outbox
+	ReminderStatusDelivered    ReminderStatus = "delivered"    // notification-service delivered it
+	ReminderStatusFailed       ReminderStatus = "failed"       // notification-service failed to deliver it
+	ReminderStatusAcknowledged ReminderStatus = "acknowledged" // user confirmed they have seen it
+	ReminderStatusCancelled    ReminderStatus = "cancelled"    // deleted by the user before it fired
+)
+
 type Reminder struct {
-	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
-	UserID      uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
-	Title       string    `gorm:"type:varchar(255);not null" json:"title"`
-	Description string    `gorm:"type:text" json:"description"`
-	RemindAt    time.Time `gorm:"type:timestamptz;not null" json:"remind_at"`
-	IsSent      bool      `gorm:"default:false" json:"is_sent"`
-	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID              uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID          uuid.UUID      `gorm:"type:uuid;not null;index" json:"user_id"`
+	Title           string         `gorm:"type:varchar(255);not null" json:"title"`
+	Description     string         `gorm:"type:text" json:"description"`
+	RemindAt        time.Time      `gorm:"type:timestamptz;not null" json:"remind_at"`
+	Status          ReminderStatus `gorm:"type:varchar(20);not null;default:'scheduled'" json:"status"`
+	RRule           string         `gorm:"column:rrule;type:text" json:"rrule,omitempty"`      // RFC 5545 RRULE, empty for one-shot reminders
+	Timezone        string         `gorm:"type:varchar(64)" json:"timezone,omitempty"`         // IANA zone the RRULE is evaluated in
+	RecurrenceStart *time.Time     `gorm:"type:timestamptz" json:"recurrence_start,omitempty"` // DTSTART the RRULE is anchored at
+	AcknowledgedAt  *time.Time     `gorm:"type:timestamptz" json:"acknowledged_at,omitempty"`
+	LastFiredAt     *time.Time     `gorm:"type:timestamptz" json:"last_fired_at,omitempty"` // Occurrence that fired last; recurring reminders stay scheduled after it
+	CorrelationID   string         `gorm:"type:varchar(64)" json:"-"`                       // Request that last scheduled it, for tracing its notification
+	CreatedAt       time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
 }
 
 func (Reminder) TableName() string {
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,18 +18,60 @@ func (User) TableName() string {
 	return "users"
 }
 
+// RefreshToken is one link of a rotation chain. Login starts a family and
+// every refresh marks the presented token used and adds its successor.
 type RefreshToken struct {
-	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
-	Token     string    `gorm:"type:varchar(512);uniqueIndex;not null" json:"token"`
-	UserID    uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
-	ExpiresAt time.Time `gorm:"not null" json:"expires_at"`
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	TokenHash string     `gorm:"type:char(64);uniqueIndex;not null" json:"-"` // HMAC-SHA256 with the server pepper, hex
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	FamilyID  uuid.UUID  `gorm:"type:uuid;not null;index" json:"family_id"`
+	ParentID  *uuid.UUID `gorm:"type:uuid" json:"parent_id"` // Token this one was rotated from
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`    // Set when rotated; presenting it again is a replay
+	RevokedAt *time.Time `json:"revoked_at"` // Set on the whole family when a replay is detected
 }
 
 func (RefreshToken) TableName() string {
 	return "refresh_tokens"
 }
 
+// Session is one login on one device. Its ID is the family ID of the refresh
+// tokens issued to it and the sid claim of its access tokens.
+type Session struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	UserAgent  string     `gorm:"type:varchar(512);not null;default:''" json:"user_agent"`
+	IP         string     `gorm:"type:varchar(45);not null;default:''" json:"ip"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	LastUsedAt time.Time  `gorm:"not null" json:"last_used_at"` // Login or latest refresh
+	ExpiresAt  time.Time  `gorm:"not null" json:"expires_at"`   // Expiry of its newest refresh token
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+func (Session) TableName() string {
+	return "sessions"
+}
+
+// SessionRevokedChannel is the Redis pub/sub channel auth-service publishes
+// revoked session IDs to, so the gateway can close streams opened with them.
+const SessionRevokedChannel = "session_revoked"
+
+const SecurityEventRefreshTokenReuse = "refresh_token_reuse"
+
+// SecurityEvent records suspicious activity on a user's account.
+type SecurityEvent struct {
+	ID        uuid.UUID       `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID    uuid.UUID       `gorm:"type:uuid;not null" json:"user_id"`
+	Type      string          `gorm:"type:varchar(50);not null" json:"type"`
+	Details   json.RawMessage `gorm:"type:jsonb;not null" json:"details"`
+	CreatedAt time.Time       `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (SecurityEvent) TableName() string {
+	return "security_events"
+}
+
 type Credentials struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook is a user-configured endpoint that receives reminders as signed JSON POSTs.
+type Webhook struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	URL       string    `gorm:"type:text;not null" json:"url"`
+	Secret    string    `gorm:"type:varchar(128);not null" json:"-"` // HMAC-SHA256 signing key
+	Enabled   bool      `gorm:"not null" json:"enabled"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (Webhook) TableName() string {
+	return "notification_webhooks"
+}
+
+// WebhookRetry is a failed delivery to one endpoint, waiting for its next attempt.
+type WebhookRetry struct {
+	ID            uuid.UUID       `gorm:"type:uuid;primaryKey" json:"id"`
+	WebhookID     uuid.UUID       `gorm:"type:uuid;not null" json:"webhook_id"`
+	ReminderID    uuid.UUID       `gorm:"type:uuid;not null" json:"reminder_id"`
+	UserID        uuid.UUID       `gorm:"type:uuid;not null" json:"user_id"`
+	EventID       uuid.UUID       `gorm:"type:uuid;not null" json:"event_id"` // Sent again as X-Reminder-Event-ID
+	Payload       json.RawMessage `gorm:"type:jsonb;not null" json:"payload"` // The exact body of the first attempt
+	Attempts      int             `gorm:"not null" json:"attempts"`           // Attempts made so far
+	LastError     string          `gorm:"type:text" json:"last_error"`
+	NextAttemptAt time.Time       `gorm:"not null;index" json:"next_attempt_at"`
+	CreatedAt     time.Time       `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (WebhookRetry) TableName() string {
+	return "notification_webhook_retries"
+}
@@ -0,0 +1,89 @@
+// Package correlation carries a request's correlation ID from the API Gateway
+// through gRPC metadata, the outbox and Kafka headers into every log line.
+package correlation
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	HTTPHeader  = "X-Correlation-ID" // Accepted from clients and echoed in responses
+	MetadataKey = "x-correlation-id" // gRPC metadata
+	KafkaHeader = "correlation-id"
+	LogKey      = "correlation_id"
+	maxLength   = 64 // Matches the outbox and reminders columns
+)
+
+type contextKey struct{}
+
+// New returns a fresh correlation ID.
+func New() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
+
+// WithID returns ctx carrying id. An empty id leaves ctx unchanged.
+func WithID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the correlation ID carried by ctx, or "".
+func FromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Valid reports whether a client-supplied ID can be used as is.
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for _, r := range id {
+		if r < 0x21 || r > 0x7e { // Printable ASCII only, it ends up in headers and logs
+			return false
+		}
+	}
+	return true
+}
+
+// UnaryClientInterceptor forwards the context's correlation ID as gRPC metadata.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if id := FromContext(ctx); id != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, MetadataKey, id)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// UnaryServerInterceptor puts the correlation ID from gRPC metadata into the handler's context.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(MetadataKey); len(values) > 0 && Valid(values[0]) {
+				ctx = WithID(ctx, values[0])
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// FromKafka returns ctx carrying the correlation ID from a consumed message's headers.
+func FromKafka(ctx context.Context, headers []kafka.Header) context.Context {
+	for _, h := range headers {
+		if h.Key == KafkaHeader && Valid(string(h.Value)) {
+			return WithID(ctx, string(h.Value))
+		}
+	}
+	return ctx
+}
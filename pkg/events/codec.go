@@ -0,0 +1,102 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/kiribu/jwt-practice/models"
+	"github.com/kiribu/jwt-practice/pkg/events/pb"
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/proto"
+)
+
+// ContentTypeHeader tells consumers how the message value is encoded.
+// Messages without it are JSON.
+const ContentTypeHeader = "content-type"
+
+const (
+	ContentTypeJSON     = "application/cloudevents+json"
+	ContentTypeProtobuf = "application/cloudevents+protobuf"
+)
+
+// Codec serializes envelopes on a Kafka topic.
+type Codec interface {
+	ContentType() string
+	Encode(envelope models.Envelope) ([]byte, error)
+	Decode(data []byte) (models.Envelope, error)
+}
+
+// NewCodec returns the codec configured for a topic: "json" (default) or "protobuf".
+func NewCodec(name string) (Codec, error) {
+	switch name {
+	case "", "json":
+		return JSONCodec{}, nil
+	case "protobuf":
+		return ProtobufCodec{}, nil
+	default:
+		return nil, fmt.Errorf("unknown event encoding %q, use json or protobuf", name)
+	}
+}
+
+// CodecFor picks the decoder for a consumed message by its content-type
+// header, so producers can switch encodings without redeploying consumers.
+func CodecFor(headers []kafka.Header) (Codec, error) {
+	for _, h := range headers {
+		if h.Key != ContentTypeHeader {
+			continue
+		}
+		switch string(h.Value) {
+		case ContentTypeJSON:
+			return JSONCodec{}, nil
+		case ContentTypeProtobuf:
+			return ProtobufCodec{}, nil
+		default:
+			return nil, fmt.Errorf("%w: content-type %q", models.ErrUnsupportedEvent, h.Value)
+		}
+	}
+	return JSONCodec{}, nil
+}
+
+// Header returns the header that marks messages written with codec.
+func Header(codec Codec) kafka.Header {
+	return kafka.Header{Key: ContentTypeHeader, Value: []byte(codec.ContentType())}
+}
+
+// JSONCodec writes CloudEvents structured-mode JSON.
+type JSONCodec struct{}
+
+func (JSONCodec) ContentType() string {
+	return ContentTypeJSON
+}
+
+func (JSONCodec) Encode(envelope models.Envelope) ([]byte, error) {
+	return json.Marshal(envelope)
+}
+
+func (JSONCodec) Decode(data []byte) (models.Envelope, error) {
+	return models.ParseEnvelope(data)
+}
+
+// ProtobufCodec writes pb.Envelope. Only the event types defined in
+// proto/events.proto can be encoded.
+type ProtobufCodec struct{}
+
+func (ProtobufCodec) ContentType() string {
+	return ContentTypeProtobuf
+}
+
+func (ProtobufCodec) Encode(envelope models.Envelope) ([]byte, error) {
+	msg, err := toProtoEnvelope(envelope)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(msg)
+}
+
+func (ProtobufCodec) Decode(data []byte) (models.Envelope, error) {
+	var msg pb.Envelope
+	if err := proto.Unmarshal(data, &msg); err != nil {
+		return models.Envelope{}, fmt.Errorf("failed to unmarshal envelope: %w", err)
+	}
+	return fromProtoEnvelope(&msg)
+}
@@ -0,0 +1,205 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kiribu/jwt-practice/models"
+	"github.com/kiribu/jwt-practice/pkg/events/pb"
+)
+
+func toProtoEnvelope(envelope models.Envelope) (*pb.Envelope, error) {
+	msg := &pb.Envelope{
+		Id:          envelope.ID.String(),
+		Source:      envelope.Source,
+		Type:        envelope.Type,
+		Specversion: envelope.SpecVersion,
+		Time:        envelope.Time.Format(time.RFC3339Nano),
+		Dataschema:  envelope.DataSchema,
+		Subject:     envelope.Subject,
+	}
+
+	switch envelope.Type {
+	case models.EventTypeReminderCreated,
+		models.EventTypeReminderUpdated,
+		models.EventTypeReminderSnoozed,
+		models.EventTypeReminderAcknowledged,
+		models.EventTypeNotificationSent,
+		models.EventTypeOccurrenceSent:
+		var data models.ReminderEventData
+		if err := json.Unmarshal(envelope.Data, &data); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s data: %w", envelope.Type, err)
+		}
+		msg.Data = &pb.Envelope_ReminderEvent{ReminderEvent: &pb.ReminderEventData{
+			ReminderId: data.ReminderID.String(),
+			UserId:     data.UserID.String(),
+			Reminder:   toProtoReminder(data.Reminder),
+		}}
+
+	case models.EventTypeReminderDeleted:
+		var data models.ReminderDeletedData
+		if err := json.Unmarshal(envelope.Data, &data); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s data: %w", envelope.Type, err)
+		}
+		msg.Data = &pb.Envelope_ReminderDeleted{ReminderDeleted: &pb.ReminderDeletedData{
+			ReminderId: data.ReminderID.String(),
+			UserId:     data.UserID.String(),
+		}}
+
+	case models.EventTypeNotificationTrigger:
+		var data models.NotificationTriggerData
+		if err := json.Unmarshal(envelope.Data, &data); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s data: %w", envelope.Type, err)
+		}
+		msg.Data = &pb.Envelope_NotificationTrigger{NotificationTrigger: &pb.NotificationTriggerData{
+			Reminder: toProtoReminder(data.Reminder),
+		}}
+
+	default:
+		return nil, fmt.Errorf("%w: no protobuf schema for type %q", models.ErrUnsupportedEvent, envelope.Type)
+	}
+
+	return msg, nil
+}
+
+func fromProtoEnvelope(msg *pb.Envelope) (models.Envelope, error) {
+	if msg.Specversion == "" {
+		return models.Envelope{}, models.ErrNoEnvelope
+	}
+	if msg.Specversion != models.EventSpecVersion {
+		return models.Envelope{}, fmt.Errorf("%w: specversion %q", models.ErrUnsupportedEvent, msg.Specversion)
+	}
+
+	id, err := uuid.Parse(msg.Id)
+	if err != nil {
+		return models.Envelope{}, fmt.Errorf("invalid event id: %w", err)
+	}
+	eventTime, err := time.Parse(time.RFC3339Nano, msg.Time)
+	if err != nil {
+		return models.Envelope{}, fmt.Errorf("invalid event time: %w", err)
+	}
+
+	var data interface{}
+	switch d := msg.Data.(type) {
+	case *pb.Envelope_ReminderEvent:
+		reminderID, userID, err := parseSubject(d.ReminderEvent.ReminderId, d.ReminderEvent.UserId)
+		if err != nil {
+			return models.Envelope{}, err
+		}
+		reminder, err := fromProtoReminder(d.ReminderEvent.GetReminder())
+		if err != nil {
+			return models.Envelope{}, err
+		}
+		data = models.ReminderEventData{ReminderID: reminderID, UserID: userID, Reminder: reminder}
+	case *pb.Envelope_ReminderDeleted:
+		reminderID, userID, err := parseSubject(d.ReminderDeleted.ReminderId, d.ReminderDeleted.UserId)
+		if err != nil {
+			return models.Envelope{}, err
+		}
+		data = models.ReminderDeletedData{ReminderID: reminderID, UserID: userID}
+	case *pb.Envelope_NotificationTrigger:
+		reminder, err := fromProtoReminder(d.NotificationTrigger.GetReminder())
+		if err != nil {
+			return models.Envelope{}, err
+		}
+		data = models.NotificationTriggerData{Reminder: reminder}
+	default:
+		// Data added to the schema after this build lands in unknown fields
+		return models.Envelope{}, fmt.Errorf("%w: type %q has no data this build understands", models.ErrUnsupportedEvent, msg.Type)
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return models.Envelope{}, fmt.Errorf("failed to marshal event data: %w", err)
+	}
+
+	return models.Envelope{
+		ID:              id,
+		Source:          msg.Source,
+		Type:            msg.Type,
+		SpecVersion:     msg.Specversion,
+		Time:            eventTime,
+		DataSchema:      msg.Dataschema,
+		DataContentType: models.EventDataContentType,
+		Subject:         msg.Subject,
+		Data:            raw,
+	}, nil
+}
+
+func parseSubject(reminderID, userID string) (uuid.UUID, uuid.UUID, error) {
+	rid, err := uuid.Parse(reminderID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("invalid reminder id: %w", err)
+	}
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("invalid user id: %w", err)
+	}
+	return rid, uid, nil
+}
+
+func toProtoReminder(r models.Reminder) *pb.Reminder {
+	msg := &pb.Reminder{
+		Id:          r.ID.String(),
+		UserId:      r.UserID.String(),
+		Title:       r.Title,
+		Description: r.Description,
+		RemindAt:    r.RemindAt.Format(time.RFC3339Nano),
+		Status:      string(r.Status),
+		Rrule:       r.RRule,
+		Timezone:    r.Timezone,
+		CreatedAt:   r.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:   r.UpdatedAt.Format(time.RFC3339Nano),
+	}
+	if r.RecurrenceStart != nil {
+		msg.RecurrenceStart = r.RecurrenceStart.Format(time.RFC3339Nano)
+	}
+	if r.AcknowledgedAt != nil {
+		msg.AcknowledgedAt = r.AcknowledgedAt.Format(time.RFC3339Nano)
+	}
+	if r.LastFiredAt != nil {
+		msg.LastFiredAt = r.LastFiredAt.Format(time.RFC3339Nano)
+	}
+	return msg
+}
+
+func fromProtoReminder(msg *pb.Reminder) (models.Reminder, error) {
+	if msg == nil {
+		return models.Reminder{}, fmt.Errorf("%w: event has no reminder", models.ErrUnsupportedEvent)
+	}
+
+	id, userID, err := parseSubject(msg.Id, msg.UserId)
+	if err != nil {
+		return models.Reminder{}, err
+	}
+
+	r := models.Reminder{
+		ID:          id,
+		UserID:      userID,
+		Title:       msg.Title,
+		Description: msg.Description,
+		Status:      models.ReminderStatus(msg.Status),
+		RRule:       msg.Rrule,
+		Timezone:    msg.Timezone,
+	}
+	r.RemindAt, _ = time.Parse(time.RFC3339Nano, msg.RemindAt)
+	r.CreatedAt, _ = time.Parse(time.RFC3339Nano, msg.CreatedAt)
+	r.UpdatedAt, _ = time.Parse(time.RFC3339Nano, msg.UpdatedAt)
+	r.RecurrenceStart = parseOptionalTime(msg.RecurrenceStart)
+	r.AcknowledgedAt = parseOptionalTime(msg.AcknowledgedAt)
+	r.LastFiredAt = parseOptionalTime(msg.LastFiredAt)
+	return r, nil
+}
+
+func parseOptionalTime(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
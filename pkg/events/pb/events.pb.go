@@ -0,0 +1,585 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v3.21.12
+// source: proto/events.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Envelope is the protobuf form of a CloudEvents 1.0 event (models.Envelope).
+type Envelope struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // UUID
+	Source      string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Type        string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"` // e.g. "com.kiribu.reminders.reminder.created.v1"
+	Specversion string                 `protobuf:"bytes,4,opt,name=specversion,proto3" json:"specversion,omitempty"`
+	Time        string                 `protobuf:"bytes,5,opt,name=time,proto3" json:"time,omitempty"` // RFC 3339 with nanoseconds
+	Dataschema  string                 `protobuf:"bytes,6,opt,name=dataschema,proto3" json:"dataschema,omitempty"`
+	Subject     string                 `protobuf:"bytes,7,opt,name=subject,proto3" json:"subject,omitempty"` // Reminder ID
+	// Types that are valid to be assigned to Data:
+	//
+	//	*Envelope_ReminderEvent
+	//	*Envelope_ReminderDeleted
+	//	*Envelope_NotificationTrigger
+	Data          isEnvelope_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Envelope) Reset() {
+	*x = Envelope{}
+	mi := &file_proto_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Envelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Envelope) ProtoMessage() {}
+
+func (x *Envelope) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Envelope.ProtoReflect.Descriptor instead.
+func (*Envelope) Descriptor() ([]byte, []int) {
+	return file_proto_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Envelope) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Envelope) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Envelope) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Envelope) GetSpecversion() string {
+	if x != nil {
+		return x.Specversion
+	}
+	return ""
+}
+
+func (x *Envelope) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *Envelope) GetDataschema() string {
+	if x != nil {
+		return x.Dataschema
+	}
+	return ""
+}
+
+func (x *Envelope) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *Envelope) GetData() isEnvelope_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Envelope) GetReminderEvent() *ReminderEventData {
+	if x != nil {
+		if x, ok := x.Data.(*Envelope_ReminderEvent); ok {
+			return x.ReminderEvent
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetReminderDeleted() *ReminderDeletedData {
+	if x != nil {
+		if x, ok := x.Data.(*Envelope_ReminderDeleted); ok {
+			return x.ReminderDeleted
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetNotificationTrigger() *NotificationTriggerData {
+	if x != nil {
+		if x, ok := x.Data.(*Envelope_NotificationTrigger); ok {
+			return x.NotificationTrigger
+		}
+	}
+	return nil
+}
+
+type isEnvelope_Data interface {
+	isEnvelope_Data()
+}
+
+type Envelope_ReminderEvent struct {
+	ReminderEvent *ReminderEventData `protobuf:"bytes,10,opt,name=reminder_event,json=reminderEvent,proto3,oneof"`
+}
+
+type Envelope_ReminderDeleted struct {
+	ReminderDeleted *ReminderDeletedData `protobuf:"bytes,11,opt,name=reminder_deleted,json=reminderDeleted,proto3,oneof"`
+}
+
+type Envelope_NotificationTrigger struct {
+	NotificationTrigger *NotificationTriggerData `protobuf:"bytes,12,opt,name=notification_trigger,json=notificationTrigger,proto3,oneof"`
+}
+
+func (*Envelope_ReminderEvent) isEnvelope_Data() {}
+
+func (*Envelope_ReminderDeleted) isEnvelope_Data() {}
+
+func (*Envelope_NotificationTrigger) isEnvelope_Data() {}
+
+type Reminder struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId          string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Title           string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description     string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	RemindAt        string                 `protobuf:"bytes,5,opt,name=remind_at,json=remindAt,proto3" json:"remind_at,omitempty"`
+	Status          string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Rrule           string                 `protobuf:"bytes,7,opt,name=rrule,proto3" json:"rrule,omitempty"`
+	Timezone        string                 `protobuf:"bytes,8,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	RecurrenceStart string                 `protobuf:"bytes,9,opt,name=recurrence_start,json=recurrenceStart,proto3" json:"recurrence_start,omitempty"` // empty if not recurring
+	AcknowledgedAt  string                 `protobuf:"bytes,10,opt,name=acknowledged_at,json=acknowledgedAt,proto3" json:"acknowledged_at,omitempty"`   // empty if not acknowledged
+	CreatedAt       string                 `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       string                 `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	LastFiredAt     string                 `protobuf:"bytes,13,opt,name=last_fired_at,json=lastFiredAt,proto3" json:"last_fired_at,omitempty"` // empty if never fired
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Reminder) Reset() {
+	*x = Reminder{}
+	mi := &file_proto_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reminder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reminder) ProtoMessage() {}
+
+func (x *Reminder) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reminder.ProtoReflect.Descriptor instead.
+func (*Reminder) Descriptor() ([]byte, []int) {
+	return file_proto_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Reminder) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Reminder) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Reminder) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Reminder) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Reminder) GetRemindAt() string {
+	if x != nil {
+		return x.RemindAt
+	}
+	return ""
+}
+
+func (x *Reminder) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Reminder) GetRrule() string {
+	if x != nil {
+		return x.Rrule
+	}
+	return ""
+}
+
+func (x *Reminder) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *Reminder) GetRecurrenceStart() string {
+	if x != nil {
+		return x.RecurrenceStart
+	}
+	return ""
+}
+
+func (x *Reminder) GetAcknowledgedAt() string {
+	if x != nil {
+		return x.AcknowledgedAt
+	}
+	return ""
+}
+
+func (x *Reminder) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Reminder) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Reminder) GetLastFiredAt() string {
+	if x != nil {
+		return x.LastFiredAt
+	}
+	return ""
+}
+
+// Data of every reminder lifecycle event except deleted.
+type ReminderEventData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReminderId    string                 `protobuf:"bytes,1,opt,name=reminder_id,json=reminderId,proto3" json:"reminder_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reminder      *Reminder              `protobuf:"bytes,3,opt,name=reminder,proto3" json:"reminder,omitempty"` // Snapshot after the change
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReminderEventData) Reset() {
+	*x = ReminderEventData{}
+	mi := &file_proto_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReminderEventData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReminderEventData) ProtoMessage() {}
+
+func (x *ReminderEventData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReminderEventData.ProtoReflect.Descriptor instead.
+func (*ReminderEventData) Descriptor() ([]byte, []int) {
+	return file_proto_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ReminderEventData) GetReminderId() string {
+	if x != nil {
+		return x.ReminderId
+	}
+	return ""
+}
+
+func (x *ReminderEventData) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReminderEventData) GetReminder() *Reminder {
+	if x != nil {
+		return x.Reminder
+	}
+	return nil
+}
+
+type ReminderDeletedData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReminderId    string                 `protobuf:"bytes,1,opt,name=reminder_id,json=reminderId,proto3" json:"reminder_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReminderDeletedData) Reset() {
+	*x = ReminderDeletedData{}
+	mi := &file_proto_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReminderDeletedData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReminderDeletedData) ProtoMessage() {}
+
+func (x *ReminderDeletedData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReminderDeletedData.ProtoReflect.Descriptor instead.
+func (*ReminderDeletedData) Descriptor() ([]byte, []int) {
+	return file_proto_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReminderDeletedData) GetReminderId() string {
+	if x != nil {
+		return x.ReminderId
+	}
+	return ""
+}
+
+func (x *ReminderDeletedData) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type NotificationTriggerData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reminder      *Reminder              `protobuf:"bytes,1,opt,name=reminder,proto3" json:"reminder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationTriggerData) Reset() {
+	*x = NotificationTriggerData{}
+	mi := &file_proto_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationTriggerData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationTriggerData) ProtoMessage() {}
+
+func (x *NotificationTriggerData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationTriggerData.ProtoReflect.Descriptor instead.
+func (*NotificationTriggerData) Descriptor() ([]byte, []int) {
+	return file_proto_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *NotificationTriggerData) GetReminder() *Reminder {
+	if x != nil {
+		return x.Reminder
+	}
+	return nil
+}
+
+var File_proto_events_proto protoreflect.FileDescriptor
+
+const file_proto_events_proto_rawDesc = "" +
+	"\n" +
+	"\x12proto/events.proto\x12\x06events\"\xa2\x03\n" +
+	"\bEnvelope\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12 \n" +
+	"\vspecversion\x18\x04 \x01(\tR\vspecversion\x12\x12\n" +
+	"\x04time\x18\x05 \x01(\tR\x04time\x12\x1e\n" +
+	"\n" +
+	"dataschema\x18\x06 \x01(\tR\n" +
+	"dataschema\x12\x18\n" +
+	"\asubject\x18\a \x01(\tR\asubject\x12B\n" +
+	"\x0ereminder_event\x18\n" +
+	" \x01(\v2\x19.events.ReminderEventDataH\x00R\rreminderEvent\x12H\n" +
+	"\x10reminder_deleted\x18\v \x01(\v2\x1b.events.ReminderDeletedDataH\x00R\x0freminderDeleted\x12T\n" +
+	"\x14notification_trigger\x18\f \x01(\v2\x1f.events.NotificationTriggerDataH\x00R\x13notificationTriggerB\x06\n" +
+	"\x04data\"\x88\x03\n" +
+	"\bReminder\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1b\n" +
+	"\tremind_at\x18\x05 \x01(\tR\bremindAt\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x14\n" +
+	"\x05rrule\x18\a \x01(\tR\x05rrule\x12\x1a\n" +
+	"\btimezone\x18\b \x01(\tR\btimezone\x12)\n" +
+	"\x10recurrence_start\x18\t \x01(\tR\x0frecurrenceStart\x12'\n" +
+	"\x0facknowledged_at\x18\n" +
+	" \x01(\tR\x0eacknowledgedAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\tR\tupdatedAt\x12\"\n" +
+	"\rlast_fired_at\x18\r \x01(\tR\vlastFiredAt\"{\n" +
+	"\x11ReminderEventData\x12\x1f\n" +
+	"\vreminder_id\x18\x01 \x01(\tR\n" +
+	"reminderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12,\n" +
+	"\breminder\x18\x03 \x01(\v2\x10.events.ReminderR\breminder\"O\n" +
+	"\x13ReminderDeletedData\x12\x1f\n" +
+	"\vreminder_id\x18\x01 \x01(\tR\n" +
+	"reminderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"G\n" +
+	"\x17NotificationTriggerData\x12,\n" +
+	"\breminder\x18\x01 \x01(\v2\x10.events.ReminderR\breminderB.Z,github.com/kiribu/jwt-practice/pkg/events/pbb\x06proto3"
+
+var (
+	file_proto_events_proto_rawDescOnce sync.Once
+	file_proto_events_proto_rawDescData []byte
+)
+
+func file_proto_events_proto_rawDescGZIP() []byte {
+	file_proto_events_proto_rawDescOnce.Do(func() {
+		file_proto_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_events_proto_rawDesc), len(file_proto_events_proto_rawDesc)))
+	})
+	return file_proto_events_proto_rawDescData
+}
+
+var file_proto_events_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_proto_events_proto_goTypes = []any{
+	(*Envelope)(nil),                // 0: events.Envelope
+	(*Reminder)(nil),                // 1: events.Reminder
+	(*ReminderEventData)(nil),       // 2: events.ReminderEventData
+	(*ReminderDeletedData)(nil),     // 3: events.ReminderDeletedData
+	(*NotificationTriggerData)(nil), // 4: events.NotificationTriggerData
+}
+var file_proto_events_proto_depIdxs = []int32{
+	2, // 0: events.Envelope.reminder_event:type_name -> events.ReminderEventData
+	3, // 1: events.Envelope.reminder_deleted:type_name -> events.ReminderDeletedData
+	4, // 2: events.Envelope.notification_trigger:type_name -> events.NotificationTriggerData
+	1, // 3: events.ReminderEventData.reminder:type_name -> events.Reminder
+	1, // 4: events.NotificationTriggerData.reminder:type_name -> events.Reminder
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_proto_events_proto_init() }
+func file_proto_events_proto_init() {
+	if File_proto_events_proto != nil {
+		return
+	}
+	file_proto_events_proto_msgTypes[0].OneofWrappers = []any{
+		(*Envelope_ReminderEvent)(nil),
+		(*Envelope_ReminderDeleted)(nil),
+		(*Envelope_NotificationTrigger)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_events_proto_rawDesc), len(file_proto_events_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_events_proto_goTypes,
+		DependencyIndexes: file_proto_events_proto_depIdxs,
+		MessageInfos:      file_proto_events_proto_msgTypes,
+	}.Build()
+	File_proto_events_proto = out.File
+	file_proto_events_proto_goTypes = nil
+	file_proto_events_proto_depIdxs = nil
+}
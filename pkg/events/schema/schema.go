@@ -0,0 +1,167 @@
+// Package schema keeps a file-backed snapshot of the event protos and checks
+// new versions against it for changes that would break existing consumers.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Snapshot is the wire-relevant shape of a proto file.
+type Snapshot struct {
+	Messages map[string]Message `json:"messages"` // Keyed by full name
+}
+
+type Message struct {
+	Fields        map[protoreflect.FieldNumber]Field `json:"fields"`
+	ReservedRange [][2]protoreflect.FieldNumber      `json:"reserved_ranges,omitempty"` // Inclusive
+}
+
+type Field struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"` // Scalar kind or full message name
+	Cardinality string `json:"cardinality"`
+	Oneof       string `json:"oneof,omitempty"`
+}
+
+// FromFile takes a snapshot of every message in a compiled proto file.
+func FromFile(file protoreflect.FileDescriptor) *Snapshot {
+	snapshot := &Snapshot{Messages: make(map[string]Message)}
+	addMessages(snapshot, file.Messages())
+	return snapshot
+}
+
+func addMessages(snapshot *Snapshot, messages protoreflect.MessageDescriptors) {
+	for i := 0; i < messages.Len(); i++ {
+		md := messages.Get(i)
+		msg := Message{Fields: make(map[protoreflect.FieldNumber]Field)}
+
+		fields := md.Fields()
+		for j := 0; j < fields.Len(); j++ {
+			fd := fields.Get(j)
+			field := Field{
+				Name:        string(fd.Name()),
+				Type:        fd.Kind().String(),
+				Cardinality: fd.Cardinality().String(),
+			}
+			if fd.Message() != nil {
+				field.Type = string(fd.Message().FullName())
+			}
+			if fd.Enum() != nil {
+				field.Type = string(fd.Enum().FullName())
+			}
+			if oneof := fd.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+				field.Oneof = string(oneof.Name())
+			}
+			msg.Fields[fd.Number()] = field
+		}
+
+		ranges := md.ReservedRanges()
+		for j := 0; j < ranges.Len(); j++ {
+			r := ranges.Get(j)
+			msg.ReservedRange = append(msg.ReservedRange, [2]protoreflect.FieldNumber{r[0], r[1] - 1})
+		}
+
+		snapshot.Messages[string(md.FullName())] = msg
+		addMessages(snapshot, md.Messages())
+	}
+}
+
+// Load reads a snapshot written by Save.
+func Load(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &snapshot, nil
+}
+
+func Save(path string, snapshot *Snapshot) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Check lists the changes from old to current that break backward
+// compatibility for consumers built against old. Adding messages and fields
+// and renaming fields are allowed; removing a message, removing a field
+// without reserving its number, reusing a reserved number, and changing a
+// field's type, cardinality or oneof are not.
+func Check(old, current *Snapshot) []string {
+	var problems []string
+
+	for _, name := range sortedKeys(old.Messages) {
+		oldMsg := old.Messages[name]
+		newMsg, ok := current.Messages[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("message %s was removed", name))
+			continue
+		}
+
+		for _, number := range sortedNumbers(oldMsg.Fields) {
+			oldField := oldMsg.Fields[number]
+			newField, ok := newMsg.Fields[number]
+			if !ok {
+				if !newMsg.reserved(number) {
+					problems = append(problems, fmt.Sprintf("%s: field %s = %d was removed without reserving its number", name, oldField.Name, number))
+				}
+				continue
+			}
+
+			if oldField.Type != newField.Type {
+				problems = append(problems, fmt.Sprintf("%s: field %s = %d changed type from %s to %s", name, oldField.Name, number, oldField.Type, newField.Type))
+			}
+			if oldField.Cardinality != newField.Cardinality {
+				problems = append(problems, fmt.Sprintf("%s: field %s = %d changed cardinality from %s to %s", name, oldField.Name, number, oldField.Cardinality, newField.Cardinality))
+			}
+			if oldField.Oneof != newField.Oneof {
+				problems = append(problems, fmt.Sprintf("%s: field %s = %d moved from oneof %q to %q", name, oldField.Name, number, oldField.Oneof, newField.Oneof))
+			}
+		}
+
+		for _, number := range sortedNumbers(newMsg.Fields) {
+			if _, existed := oldMsg.Fields[number]; !existed && oldMsg.reserved(number) {
+				problems = append(problems, fmt.Sprintf("%s: field %s reuses reserved number %d", name, newMsg.Fields[number].Name, number))
+			}
+		}
+	}
+
+	return problems
+}
+
+func (m Message) reserved(number protoreflect.FieldNumber) bool {
+	for _, r := range m.ReservedRange {
+		if number >= r[0] && number <= r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(messages map[string]Message) []string {
+	keys := make([]string, 0, len(messages))
+	for k := range messages {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedNumbers(fields map[protoreflect.FieldNumber]Field) []protoreflect.FieldNumber {
+	numbers := make([]protoreflect.FieldNumber, 0, len(fields))
+	for n := range fields {
+		numbers = append(numbers, n)
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+	return numbers
+}
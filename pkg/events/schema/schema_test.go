@@ -0,0 +1,143 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kiribu/jwt-practice/pkg/events/pb"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const lockPath = "../../../proto/events.lock.json"
+
+// The compiled protos must stay compatible with the committed snapshot; run
+// go run ./cmd/schema-check -update after an intended, compatible change.
+func TestCompiledSchemaMatchesLock(t *testing.T) {
+	lock, err := Load(lockPath)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if problems := Check(lock, FromFile(pb.File_proto_events_proto)); len(problems) > 0 {
+		t.Fatalf("proto/events.proto breaks existing consumers:\n%s", strings.Join(problems, "\n"))
+	}
+}
+
+func TestCheck(t *testing.T) {
+	const envelope = "events.Envelope"
+
+	tests := []struct {
+		name   string
+		change func(old, current *Snapshot)
+		want   string // Substring of the only problem, empty if compatible
+	}{
+		{
+			name:   "unchanged",
+			change: func(old, current *Snapshot) {},
+		},
+		{
+			name: "added field",
+			change: func(old, current *Snapshot) {
+				current.Messages[envelope].Fields[99] = Field{Name: "extra", Type: "string", Cardinality: "optional"}
+			},
+		},
+		{
+			name: "renamed field",
+			change: func(old, current *Snapshot) {
+				field := current.Messages[envelope].Fields[7]
+				field.Name = "reminder_id"
+				current.Messages[envelope].Fields[7] = field
+			},
+		},
+		{
+			name: "removed field with reserved number",
+			change: func(old, current *Snapshot) {
+				delete(current.Messages[envelope].Fields, 7)
+				reserve(current, envelope, 7)
+			},
+		},
+		{
+			name: "removed field",
+			change: func(old, current *Snapshot) {
+				delete(current.Messages[envelope].Fields, 7)
+			},
+			want: "field subject = 7 was removed without reserving its number",
+		},
+		{
+			name: "removed message",
+			change: func(old, current *Snapshot) {
+				delete(current.Messages, envelope)
+			},
+			want: "message events.Envelope was removed",
+		},
+		{
+			name: "changed type",
+			change: func(old, current *Snapshot) {
+				field := current.Messages[envelope].Fields[5]
+				field.Type = "events.Timestamp"
+				current.Messages[envelope].Fields[5] = field
+			},
+			want: "field time = 5 changed type from string to events.Timestamp",
+		},
+		{
+			name: "changed cardinality",
+			change: func(old, current *Snapshot) {
+				field := current.Messages[envelope].Fields[2]
+				field.Cardinality = "repeated"
+				current.Messages[envelope].Fields[2] = field
+			},
+			want: "field source = 2 changed cardinality from optional to repeated",
+		},
+		{
+			name: "moved out of oneof",
+			change: func(old, current *Snapshot) {
+				field := current.Messages[envelope].Fields[10]
+				field.Oneof = ""
+				current.Messages[envelope].Fields[10] = field
+			},
+			want: `field reminder_event = 10 moved from oneof "data" to ""`,
+		},
+		{
+			name: "reused reserved number",
+			change: func(old, current *Snapshot) {
+				reserve(old, envelope, 8)
+				current.Messages[envelope].Fields[8] = Field{Name: "legacy", Type: "int64", Cardinality: "optional"}
+			},
+			want: "field legacy reuses reserved number 8",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old, current := loadLock(t), loadLock(t)
+			tt.change(old, current)
+
+			problems := Check(old, current)
+			if tt.want == "" {
+				if len(problems) > 0 {
+					t.Fatalf("Check = %q, want compatible", problems)
+				}
+				return
+			}
+			if len(problems) != 1 || !strings.Contains(problems[0], tt.want) {
+				t.Fatalf("Check = %q, want one problem containing %q", problems, tt.want)
+			}
+		})
+	}
+}
+
+// loadLock reads the committed snapshot; every call returns a fresh copy.
+func loadLock(t *testing.T) *Snapshot {
+	t.Helper()
+	lock, err := Load(lockPath)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return lock
+}
+
+func reserve(snapshot *Snapshot, name string, number protoreflect.FieldNumber) {
+	msg := snapshot.Messages[name]
+	msg.ReservedRange = append(msg.ReservedRange, [2]protoreflect.FieldNumber{number, number})
+	snapshot.Messages[name] = msg
+}
@@ -0,0 +1,33 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/kiribu/jwt-practice/pkg/correlation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// contextHandler adds the correlation ID and trace ID from the context to
+// records logged with the *Context variants (slog.InfoContext and friends).
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := correlation.FromContext(ctx); id != "" {
+		r.AddAttrs(slog.String(correlation.LogKey, id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String("trace_id", sc.TraceID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
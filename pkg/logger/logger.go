@@ -24,7 +24,7 @@ func Setup(env string) *slog.Logger {
 		})
 	}
 
-	logger := slog.New(handler)
+	logger := slog.New(contextHandler{handler})
 	slog.SetDefault(logger)
 
 	return logger
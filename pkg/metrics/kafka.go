@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/segmentio/kafka-go"
+)
+
+// Reasons a consumed message was not processed, for ConsumerError.
+const (
+	ReasonDecode      = "decode"
+	ReasonUnsupported = "unsupported"
+	ReasonProcess     = "process"
+	ReasonCommit      = "commit"
+)
+
+var (
+	consumerMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kafka_consumer_messages_total",
+		Help: "Messages fetched by a consumer group.",
+	}, []string{"topic", "group"})
+
+	consumerLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kafka_consumer_lag",
+		Help: "Messages behind the partition's high watermark when the last message was fetched.",
+	}, []string{"topic", "group", "partition"})
+
+	consumerErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kafka_consumer_errors_total",
+		Help: "Messages a consumer group failed to handle, by reason.",
+	}, []string{"topic", "group", "reason"})
+)
+
+// ObserveFetch counts a fetched message and updates its partition's lag.
+func ObserveFetch(m kafka.Message, group string) {
+	consumerMessages.WithLabelValues(m.Topic, group).Inc()
+
+	lag := m.HighWaterMark - m.Offset - 1
+	if lag < 0 {
+		lag = 0
+	}
+	consumerLag.WithLabelValues(m.Topic, group, strconv.Itoa(m.Partition)).Set(float64(lag))
+}
+
+// ConsumerError counts a message that failed for reason.
+func ConsumerError(m kafka.Message, group, reason string) {
+	consumerErrors.WithLabelValues(m.Topic, group, reason).Inc()
+}
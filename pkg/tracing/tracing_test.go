@@ -0,0 +1,132 @@
+package tracing_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kiribu/jwt-practice/pkg/tracing"
+	"github.com/labstack/echo/v4"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// A request through the gateway, a gRPC call, the outbox row it writes, and
+// the Kafka message published from that row all end up in one trace, each
+// span a child of the one before.
+func TestTraceFollowsRequestToKafkaConsumer(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := tracing.NewProvider("test", sdktrace.NewSimpleSpanProcessor(exporter))
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	// The gRPC service stores the traceparent with its outbox row
+	var outboxTraceParent string
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			outboxTraceParent = tracing.TraceParent(ctx)
+			return handler(ctx, req)
+		}),
+	)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	e := echo.New()
+	e.Use(otelecho.Middleware("api-gateway"))
+	e.POST("/reminders", func(c echo.Context) error {
+		_, err := healthpb.NewHealthClient(conn).Check(c.Request().Context(), &healthpb.HealthCheckRequest{})
+		if err != nil {
+			return err
+		}
+		return c.NoContent(http.StatusCreated)
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/reminders", nil))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST /reminders = %d, want 201", rec.Code)
+	}
+	if outboxTraceParent == "" {
+		t.Fatal("no traceparent in the gRPC handler's context")
+	}
+
+	// The outbox worker publishes the row later, outside the request
+	msg := kafka.Message{Topic: "reminder_events", Key: []byte("user")}
+	_, publish := tracing.StartPublish(tracing.WithTraceParent(context.Background(), outboxTraceParent), &msg, msg.Topic)
+	publish.End()
+
+	// A consumer in another service only has the message headers
+	_, process := tracing.StartProcess(context.Background(), msg, "analytics-service")
+	process.End()
+
+	spans := exporter.GetSpans()
+	chain := []tracetest.SpanStub{
+		findSpan(t, spans, trace.SpanKindServer, "/reminders"),
+		findSpan(t, spans, trace.SpanKindClient, "grpc.health.v1.Health/Check"),
+		findSpan(t, spans, trace.SpanKindServer, "grpc.health.v1.Health/Check"),
+		findSpan(t, spans, trace.SpanKindProducer, "publish reminder_events"),
+		findSpan(t, spans, trace.SpanKindConsumer, "process reminder_events"),
+	}
+
+	for i := 1; i < len(chain); i++ {
+		parent, child := chain[i-1], chain[i]
+		if child.SpanContext.TraceID() != chain[0].SpanContext.TraceID() {
+			t.Errorf("%q is in trace %s, want %s", child.Name, child.SpanContext.TraceID(), chain[0].SpanContext.TraceID())
+		}
+		if child.Parent.SpanID() != parent.SpanContext.SpanID() {
+			t.Errorf("parent of %q is %s, want %q (%s)", child.Name, child.Parent.SpanID(), parent.Name, parent.SpanContext.SpanID())
+		}
+	}
+}
+
+func findSpan(t *testing.T, spans tracetest.SpanStubs, kind trace.SpanKind, name string) tracetest.SpanStub {
+	t.Helper()
+	for _, span := range spans {
+		if span.SpanKind == kind && strings.Contains(span.Name, name) {
+			return span
+		}
+	}
+	t.Fatalf("no %s span named %q among %d spans", kind, name, len(spans))
+	return tracetest.SpanStub{}
+}
+
+func TestWithTraceParentIgnoresMalformedValues(t *testing.T) {
+	for _, value := range []string{"", "not-a-traceparent"} {
+		ctx := tracing.WithTraceParent(context.Background(), value)
+		if trace.SpanContextFromContext(ctx).IsValid() {
+			t.Errorf("WithTraceParent(%q) set a parent span", value)
+		}
+	}
+}
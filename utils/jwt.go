@@ -1,71 +1,205 @@
 package utils
 
 import (
+	"crypto/ed25519"
 	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/base64"
+	"encoding/pem"
 	"errors"
+	"fmt"
+	"math/big"
 	"os"
-	"sync"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var (
-	accessSecret []byte
-	once         sync.Once
-)
-
 const (
 	AccessTokenDuration  = 15 * time.Minute
 	RefreshTokenDuration = 2 * time.Hour
 )
 
+// minRSABits is the smallest RSA key LoadKeyRing accepts.
+const minRSABits = 2048
+
 type Claims struct {
-	Username string `json:"username"`
-	UserID   string `json:"user_id"` // UUID as string
+	Username  string `json:"username"`
+	UserID    string `json:"user_id"`       // UUID as string
+	SessionID string `json:"sid,omitempty"` // Session the token was issued for
 	jwt.RegisteredClaims
 }
 
-// getAccessSecret returns JWT secret with lazy initialization
-func getAccessSecret() []byte {
-	once.Do(func() {
-		accessSecret = getJWTSecret()
-	})
-	return accessSecret
+// JSONWebKey is a public key in JWK format (RFC 7517).
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv,omitempty"` // OKP
+	X   string `json:"x,omitempty"`   // OKP
+	N   string `json:"n,omitempty"`   // RSA
+	E   string `json:"e,omitempty"`   // RSA
+}
+
+type signingKey struct {
+	kid     string
+	method  jwt.SigningMethod
+	private interface{} // nil for keys that only verify
+	public  interface{}
+}
+
+// KeyRing holds the keys access tokens are signed and verified with. Tokens
+// are signed with the active key and carry its ID in the kid header. Every key
+// in the ring verifies tokens with its kid, so after a rotation tokens signed
+// with the previous (retiring) key stay valid until they expire.
+type KeyRing struct {
+	active       *signingKey
+	keys         map[string]*signingKey
+	legacySecret []byte
+}
+
+// LoadKeyRing loads every *.pem file in dir; the file name without .pem is
+// the key ID. Files hold a PKCS#8 private key (RSA or Ed25519), a PKCS#1 RSA
+// private key or a PKIX public key. activeKID names the signing key and may be
+// empty if dir holds exactly one private key. A non-empty legacySecret also
+// accepts HS256 tokens without a kid, issued before the key ring.
+func LoadKeyRing(dir, activeKID string, legacySecret []byte) (*KeyRing, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	ring := &KeyRing{keys: make(map[string]*signingKey), legacySecret: legacySecret}
+	var private []string
+	for _, file := range files {
+		key, err := loadKey(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load key %s: %w", file, err)
+		}
+		ring.keys[key.kid] = key
+		if key.private != nil {
+			private = append(private, key.kid)
+		}
+	}
+
+	if len(ring.keys) == 0 {
+		return nil, fmt.Errorf("no *.pem keys found in %s", dir)
+	}
+
+	if activeKID == "" {
+		if len(private) != 1 {
+			return nil, fmt.Errorf("%d private keys found in %s, set the active key ID", len(private), dir)
+		}
+		activeKID = private[0]
+	}
+
+	active, ok := ring.keys[activeKID]
+	if !ok {
+		return nil, fmt.Errorf("active key %q not found in %s", activeKID, dir)
+	}
+	if active.private == nil {
+		return nil, fmt.Errorf("active key %q has no private key", activeKID)
+	}
+	ring.active = active
+
+	return ring, nil
+}
+
+func loadKey(file string) (*signingKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := &signingKey{kid: strings.TrimSuffix(filepath.Base(file), ".pem")}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodRS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.method, key.public = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodEdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.method, key.public = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("unsupported key type %T, use RSA or Ed25519", parsed)
+	}
+
+	if rsaKey, ok := key.public.(*rsa.PublicKey); ok && rsaKey.N.BitLen() < minRSABits {
+		return nil, fmt.Errorf("RSA key is %d bits, at least %d required", rsaKey.N.BitLen(), minRSABits)
+	}
+
+	return key, nil
+}
+
+// ActiveKeyID returns the ID of the key new tokens are signed with.
+func (r *KeyRing) ActiveKeyID() string {
+	return r.active.kid
 }
 
-func GenerateAccessToken(username, userID string) (string, error) {
+func (r *KeyRing) GenerateAccessToken(username, userID, sessionID string) (string, error) {
 	claims := &Claims{
-		Username: username,
-		UserID:   userID, // Store UUID as string
+		Username:  username,
+		UserID:    userID, // Store UUID as string
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenDuration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(getAccessSecret())
-}
-
-func GenerateRefreshToken() (string, error) {
-	b := make([]byte, 32)
-	_, err := rand.Read(b)
-	if err != nil {
-		return "", err
-	}
-	return base64.URLEncoding.EncodeToString(b), nil
+	token := jwt.NewWithClaims(r.active.method, claims)
+	token.Header["kid"] = r.active.kid
+	return token.SignedString(r.active.private)
 }
 
-func ValidateAccessToken(tokenString string) (*Claims, error) {
+func (r *KeyRing) ValidateAccessToken(tokenString string) (*Claims, error) {
 	claims := &Claims{}
 
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok && len(r.legacySecret) > 0 {
+				return r.legacySecret, nil
+			}
+			return nil, errors.New("token has no kid")
+		}
+
+		key, ok := r.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown kid %q", kid)
+		}
+		// The algorithm comes from the key, never from the token
+		if token.Method.Alg() != key.method.Alg() {
 			return nil, errors.New("unexpected signing method")
 		}
-		return getAccessSecret(), nil
+		return key.public, nil
 	})
 
 	if err != nil {
@@ -79,10 +213,44 @@ func ValidateAccessToken(tokenString string) (*Claims, error) {
 	return claims, nil
 }
 
-func getJWTSecret() []byte {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		panic("JWT_SECRET environment variable is required but not set")
+// JWKS returns the public keys of the ring, active key first, for the
+// /.well-known/jwks.json document.
+func (r *KeyRing) JWKS() []JSONWebKey {
+	kids := make([]string, 0, len(r.keys))
+	for kid := range r.keys {
+		if kid != r.active.kid {
+			kids = append(kids, kid)
+		}
+	}
+	sort.Strings(kids)
+
+	keys := make([]JSONWebKey, 0, len(r.keys))
+	for _, kid := range append([]string{r.active.kid}, kids...) {
+		keys = append(keys, r.keys[kid].jwk())
+	}
+	return keys
+}
+
+func (k *signingKey) jwk() JSONWebKey {
+	jwk := JSONWebKey{Kid: k.kid, Use: "sig", Alg: k.method.Alg()}
+	switch public := k.public.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
 	}
-	return []byte(secret)
+	return jwk
+}
+
+func GenerateRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(b), nil
 }
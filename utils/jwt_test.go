@@ -0,0 +1,252 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	testEd25519Key ed25519.PrivateKey
+	testRSAKey     *rsa.PrivateKey
+)
+
+func init() {
+	var err error
+	if _, testEd25519Key, err = ed25519.GenerateKey(rand.Reader); err != nil {
+		panic(err)
+	}
+	if testRSAKey, err = rsa.GenerateKey(rand.Reader, minRSABits); err != nil {
+		panic(err)
+	}
+}
+
+// writeKey stores key in dir as <kid>.pem: PKCS#8 for private keys, PKIX for
+// public ones.
+func writeKey(t *testing.T, dir, kid string, key any) {
+	t.Helper()
+	var block *pem.Block
+	switch key.(type) {
+	case ed25519.PrivateKey, *rsa.PrivateKey:
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatalf("marshal private key: %v", err)
+		}
+		block = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	default:
+		der, err := x509.MarshalPKIXPublicKey(key)
+		if err != nil {
+			t.Fatalf("marshal public key: %v", err)
+		}
+		block = &pem.Block{Type: "PUBLIC KEY", Bytes: der}
+	}
+	if err := os.WriteFile(filepath.Join(dir, kid+".pem"), pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+}
+
+func loadTestRing(t *testing.T, dir, activeKID string, legacySecret []byte) *KeyRing {
+	t.Helper()
+	ring, err := LoadKeyRing(dir, activeKID, legacySecret)
+	if err != nil {
+		t.Fatalf("LoadKeyRing: %v", err)
+	}
+	return ring
+}
+
+// signToken signs a short-lived token with method and key, setting the kid
+// header unless kid is empty.
+func signToken(t *testing.T, method jwt.SigningMethod, kid string, key any) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, &Claims{
+		Username: "alice",
+		UserID:   "user",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+	})
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return signed
+}
+
+func TestKeyRingRejectsUnknownKid(t *testing.T) {
+	dir := t.TempDir()
+	writeKey(t, dir, "current", testEd25519Key)
+	ring := loadTestRing(t, dir, "", nil)
+
+	_, other, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	token := signToken(t, jwt.SigningMethodEdDSA, "elsewhere", other)
+	if _, err := ring.ValidateAccessToken(token); err == nil {
+		t.Error("token with an unknown kid was accepted")
+	}
+}
+
+// The verifying algorithm comes from the key the kid names; a token claiming
+// another algorithm is rejected even when its signature matches that one.
+func TestKeyRingRejectsAlgorithmMismatch(t *testing.T) {
+	dir := t.TempDir()
+	writeKey(t, dir, "ed", testEd25519Key)
+	writeKey(t, dir, "rsa", testRSAKey)
+	ring := loadTestRing(t, dir, "ed", nil)
+
+	edPublic, err := x509.MarshalPKIXPublicKey(testEd25519Key.Public())
+	if err != nil {
+		t.Fatalf("marshal public key: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"RS256 header on the Ed25519 key", signToken(t, jwt.SigningMethodRS256, "ed", testRSAKey)},
+		{"EdDSA header on the RSA key", signToken(t, jwt.SigningMethodEdDSA, "rsa", testEd25519Key)},
+		{"HS256 keyed with the public key", signToken(t, jwt.SigningMethodHS256, "ed", edPublic)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ring.ValidateAccessToken(tt.token); err == nil {
+				t.Error("token was accepted")
+			}
+		})
+	}
+
+	// The same keys with their own algorithms verify
+	if _, err := ring.ValidateAccessToken(signToken(t, jwt.SigningMethodEdDSA, "ed", testEd25519Key)); err != nil {
+		t.Errorf("EdDSA token on the Ed25519 key: %v", err)
+	}
+	if _, err := ring.ValidateAccessToken(signToken(t, jwt.SigningMethodRS256, "rsa", testRSAKey)); err != nil {
+		t.Errorf("RS256 token on the RSA key: %v", err)
+	}
+}
+
+// After a rotation the new key signs, and tokens signed with the retiring key
+// stay valid until they expire.
+func TestKeyRingRotation(t *testing.T) {
+	before := t.TempDir()
+	writeKey(t, before, "2026-01", testEd25519Key)
+	old := loadTestRing(t, before, "", nil)
+	issued, err := old.GenerateAccessToken("alice", "user", "session")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken: %v", err)
+	}
+
+	// The retiring key is kept as a public key only
+	after := t.TempDir()
+	writeKey(t, after, "2026-01", testEd25519Key.Public())
+	writeKey(t, after, "2026-02", testRSAKey)
+	ring := loadTestRing(t, after, "", nil)
+
+	if ring.ActiveKeyID() != "2026-02" {
+		t.Errorf("active key = %s, want the only private key 2026-02", ring.ActiveKeyID())
+	}
+	claims, err := ring.ValidateAccessToken(issued)
+	if err != nil {
+		t.Fatalf("token signed with the retiring key: %v", err)
+	}
+	if claims.SessionID != "session" {
+		t.Errorf("sid = %q, want session", claims.SessionID)
+	}
+
+	fresh, err := ring.GenerateAccessToken("alice", "user", "session")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken: %v", err)
+	}
+	parsed, _, err := jwt.NewParser().ParseUnverified(fresh, &Claims{})
+	if err != nil {
+		t.Fatalf("ParseUnverified: %v", err)
+	}
+	if parsed.Header["kid"] != "2026-02" || parsed.Method.Alg() != "RS256" {
+		t.Errorf("new token has kid %v and alg %s, want 2026-02 and RS256", parsed.Header["kid"], parsed.Method.Alg())
+	}
+	if _, err := old.ValidateAccessToken(fresh); err == nil {
+		t.Error("ring without the new key accepted its token")
+	}
+
+	// A public key cannot sign
+	if _, err := LoadKeyRing(after, "2026-01", nil); err == nil {
+		t.Error("LoadKeyRing made a public key active")
+	}
+}
+
+// HS256 tokens without a kid predate the key ring and are only accepted while
+// JWT_SECRET is still set.
+func TestKeyRingLegacySecret(t *testing.T) {
+	dir := t.TempDir()
+	writeKey(t, dir, "current", testEd25519Key)
+	secret := []byte("legacy-secret")
+	legacy := signToken(t, jwt.SigningMethodHS256, "", secret)
+
+	if _, err := loadTestRing(t, dir, "", secret).ValidateAccessToken(legacy); err != nil {
+		t.Errorf("legacy token with JWT_SECRET set: %v", err)
+	}
+	if _, err := loadTestRing(t, dir, "", nil).ValidateAccessToken(legacy); err == nil {
+		t.Error("legacy token accepted without JWT_SECRET")
+	}
+	forged := signToken(t, jwt.SigningMethodHS256, "", []byte("guessed"))
+	if _, err := loadTestRing(t, dir, "", secret).ValidateAccessToken(forged); err == nil {
+		t.Error("legacy token signed with another secret was accepted")
+	}
+	withoutKid := signToken(t, jwt.SigningMethodEdDSA, "", testEd25519Key)
+	if _, err := loadTestRing(t, dir, "", secret).ValidateAccessToken(withoutKid); err == nil {
+		t.Error("EdDSA token without a kid was accepted as legacy")
+	}
+}
+
+// Services verifying tokens rebuild the public keys from the JWKS document.
+func TestJWKSRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	writeKey(t, dir, "ed", testEd25519Key)
+	writeKey(t, dir, "rsa", testRSAKey)
+	ring := loadTestRing(t, dir, "rsa", nil)
+
+	keys := ring.JWKS()
+	if len(keys) != 2 || keys[0].Kid != "rsa" {
+		t.Fatalf("JWKS = %+v, want the active rsa key first", keys)
+	}
+
+	decode := func(s string) []byte {
+		t.Helper()
+		b, err := base64.RawURLEncoding.DecodeString(s)
+		if err != nil {
+			t.Fatalf("decode %q: %v", s, err)
+		}
+		return b
+	}
+
+	for _, jwk := range keys {
+		switch jwk.Kid {
+		case "rsa":
+			public := &rsa.PublicKey{N: new(big.Int).SetBytes(decode(jwk.N)), E: int(new(big.Int).SetBytes(decode(jwk.E)).Int64())}
+			if jwk.Kty != "RSA" || jwk.Alg != "RS256" || !public.Equal(&testRSAKey.PublicKey) {
+				t.Errorf("rsa JWK %+v does not match the loaded key", jwk)
+			}
+		case "ed":
+			public := ed25519.PublicKey(decode(jwk.X))
+			if jwk.Kty != "OKP" || jwk.Crv != "Ed25519" || jwk.Alg != "EdDSA" || !public.Equal(testEd25519Key.Public()) {
+				t.Errorf("ed JWK %+v does not match the loaded key", jwk)
+			}
+		}
+		if jwk.Use != "sig" {
+			t.Errorf("%s JWK use = %q, want sig", jwk.Kid, jwk.Use)
+		}
+	}
+}